@@ -3,16 +3,51 @@ package config
 import (
 	"log"
 	"os"
+	"path/filepath"
 
 	"github.com/joho/godotenv"
 )
 
 // Глобальные переменные для хранения значений из окружения.
 var (
-	Port        string // Порт приложения (из TODO_PORT)
-	DatabaseURL string // Путь к БД (из TODO_DBFILE)
-	Password    string // Мастер‑пароль (из TODO_PASSWORD)
-	JWTSecret   string // Секрет для подписи JWT (из TODO_JWT_SECRET)
+	Port         string // Порт приложения (из TODO_PORT)
+	DatabaseURL  string // Путь к БД (из TODO_DBFILE)
+	Password     string // Мастер‑пароль (из TODO_PASSWORD)
+	PasswordHash string // bcrypt-хэш мастер‑пароля (из TODO_PASSWORD_HASH)
+	JWTSecret    string // Секрет для подписи JWT (из TODO_JWT_SECRET)
+	JWTTTL       string // Время жизни JWT-токена (из TODO_JWT_TTL)
+	APIPrefix    string // Базовый путь API‑эндпоинтов (из TODO_API_PREFIX)
+
+	IdempotencyTTL string // Время жизни ключей идемпотентности (из TODO_IDEMPOTENCY_TTL)
+	ReadOnly       string // Режим "только чтение" при запуске (из TODO_READ_ONLY)
+
+	UniqueTitlePerDate string // Уникальность заголовка задачи в пределах даты (из TODO_UNIQUE_TITLE_PER_DATE)
+	MonthDayClamp      string // Клампинг дней месяца в правиле "m" (из TODO_MONTH_DAY_CLAMP)
+	SearchDateFormats  string // Форматы дат, распознаваемые в поиске (из TODO_SEARCH_DATE_FORMATS)
+
+	ContentSecurityPolicy string // Значение заголовка Content-Security-Policy (из TODO_CSP)
+	DefaultComment        string // Комментарий по умолчанию для новых задач (из TODO_DEFAULT_COMMENT)
+	MinPasswordLength     string // Минимальная длина мастер‑пароля (из TODO_MIN_PASSWORD_LENGTH)
+	SnippetLength         string // Длина фрагмента комментария в результатах поиска (из TODO_SNIPPET_LENGTH)
+	SearchComments        string // Искать ли по тексту комментариев (из TODO_SEARCH_COMMENTS)
+
+	DataDir     string // Базовая директория для относительных путей к файлам (из TODO_DATA_DIR)
+	DebugErrors string // Подробные сообщения о внутренних ошибках в ответах API (из TODO_DEBUG_ERRORS)
+
+	NoWeekendTasks string // Запрет или перенос задач на выходные (из TODO_NO_WEEKEND_TASKS)
+	SelfTest       string // Самопроверка БД при запуске (из TODO_SELFTEST)
+
+	RepeatMaxDays     string // Максимальный интервал правила "d" (из TODO_REPEAT_MAX_DAYS)
+	RepeatMaxMonthDay string // Максимальный день месяца в правиле "m" (из TODO_REPEAT_MAX_MONTH_DAY)
+	LeapDayPolicy     string // Правило "y" для задач от 29 февраля (из TODO_LEAP_DAY_POLICY)
+
+	TxImmediate string // Захват блокировки записи в начале транзакции (из TODO_TX_IMMEDIATE)
+
+	BackupOnStart  string // Резервная копия файла БД при запуске (из TODO_BACKUP_ON_START)
+	BackupKeep     string // Количество хранимых резервных копий БД (из TODO_BACKUP_KEEP)
+	AdminEndpoints string // Включить административные эндпоинты обслуживания БД (из TODO_ADMIN_ENDPOINTS)
+
+	ShutdownTimeout string // Время на завершение активных запросов при остановке (из TODO_SHUTDOWN_TIMEOUT)
 )
 
 // LoadEnv загружает переменные окружения из .env‑файла.
@@ -29,17 +64,55 @@ func LoadEnv() error {
 		// Если файл не найден - это не критичная ошибка: продолжаем, используя системные переменные
 		if os.IsNotExist(err) {
 			log.Println(".env file not found, using system environment variables")
-			return nil
+		} else {
+			// Любая другая ошибка (например, проблемы с правами, синтаксис .env) - критична
+			return err
 		}
-		// Любая другая ошибка (например, проблемы с правами, синтаксис .env) - критична
-		return err
 	}
 
 	// Загружаем значения из окружения (после загрузки .env они доступны через os.Getenv)
 	Port = os.Getenv("TODO_PORT")
 	DatabaseURL = os.Getenv("TODO_DBFILE")
 	Password = os.Getenv("TODO_PASSWORD")
+	PasswordHash = os.Getenv("TODO_PASSWORD_HASH")
 	JWTSecret = os.Getenv("TODO_JWT_SECRET")
+	JWTTTL = os.Getenv("TODO_JWT_TTL")
+	APIPrefix = os.Getenv("TODO_API_PREFIX")
+	IdempotencyTTL = os.Getenv("TODO_IDEMPOTENCY_TTL")
+	ReadOnly = os.Getenv("TODO_READ_ONLY")
+	UniqueTitlePerDate = os.Getenv("TODO_UNIQUE_TITLE_PER_DATE")
+	MonthDayClamp = os.Getenv("TODO_MONTH_DAY_CLAMP")
+	SearchDateFormats = os.Getenv("TODO_SEARCH_DATE_FORMATS")
+	ContentSecurityPolicy = os.Getenv("TODO_CSP")
+	DefaultComment = os.Getenv("TODO_DEFAULT_COMMENT")
+	MinPasswordLength = os.Getenv("TODO_MIN_PASSWORD_LENGTH")
+	SnippetLength = os.Getenv("TODO_SNIPPET_LENGTH")
+	SearchComments = os.Getenv("TODO_SEARCH_COMMENTS")
+	DataDir = os.Getenv("TODO_DATA_DIR")
+	DebugErrors = os.Getenv("TODO_DEBUG_ERRORS")
+	NoWeekendTasks = os.Getenv("TODO_NO_WEEKEND_TASKS")
+	SelfTest = os.Getenv("TODO_SELFTEST")
+	RepeatMaxDays = os.Getenv("TODO_REPEAT_MAX_DAYS")
+	RepeatMaxMonthDay = os.Getenv("TODO_REPEAT_MAX_MONTH_DAY")
+	LeapDayPolicy = os.Getenv("TODO_LEAP_DAY_POLICY")
+	TxImmediate = os.Getenv("TODO_TX_IMMEDIATE")
+	BackupOnStart = os.Getenv("TODO_BACKUP_ON_START")
+	BackupKeep = os.Getenv("TODO_BACKUP_KEEP")
+	AdminEndpoints = os.Getenv("TODO_ADMIN_ENDPOINTS")
+	ShutdownTimeout = os.Getenv("TODO_SHUTDOWN_TIMEOUT")
 
 	return nil
 }
+
+// ResolvePath разрешает путь из переменной окружения относительно базовой директории DataDir (TODO_DATA_DIR).
+// Абсолютные пути, пустое значение и пути при незаданной DataDir возвращаются без изменений
+// (то есть относительно текущей рабочей директории).
+// Параметры:
+// path - путь к файлу или директории.
+// Возвращает: путь с учётом DataDir.
+func ResolvePath(path string) string {
+	if path == "" || DataDir == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(DataDir, path)
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+// Режимы проверки мастер‑пароля (см. PasswordMode).
+const (
+	PasswordModeNone   = "none"   // пароль не задан: аутентификация отключена
+	PasswordModePlain  = "plain"  // пароль в открытом виде (TODO_PASSWORD), оставлен для совместимости
+	PasswordModeBcrypt = "bcrypt" // bcrypt-хэш пароля (TODO_PASSWORD_HASH)
+)
+
+// PasswordMode возвращает активный режим проверки пароля.
+// Хэш из TODO_PASSWORD_HASH имеет приоритет: если он задан, TODO_PASSWORD не используется.
+func PasswordMode() string {
+	switch {
+	case PasswordHash != "":
+		return PasswordModeBcrypt
+	case Password != "":
+		return PasswordModePlain
+	default:
+		return PasswordModeNone
+	}
+}
+
+// AuthEnabled сообщает, включена ли аутентификация (задан пароль или его хэш).
+func AuthEnabled() bool {
+	return PasswordMode() != PasswordModeNone
+}
+
+// PasswordFingerprint возвращает SHA-256 (в шестнадцатеричном виде) от текущих учётных данных:
+// от bcrypt-хэша в режиме PasswordModeBcrypt, иначе от пароля.
+// Значение записывается в JWT-токен, поэтому смена пароля (или его хэша) делает выданные токены недействительными.
+func PasswordFingerprint() string {
+	secret := Password
+	if PasswordMode() == PasswordModeBcrypt {
+		secret = PasswordHash
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(secret)))
+}
@@ -1,18 +1,45 @@
 package handlers
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	"go-task-manager-final_project/config"
 	"go-task-manager-final_project/internal/api"
 	"go-task-manager-final_project/internal/db"
 	"go-task-manager-final_project/internal/scheduler"
 )
 
+// defaultIdempotencyTTL - время жизни ключа идемпотентности по умолчанию.
+const defaultIdempotencyTTL = 24 * time.Hour
+
+// getIdempotencyTTL возвращает время жизни ключей идемпотентности из переменной окружения TODO_IDEMPOTENCY_TTL.
+// Если значение не задано или некорректно (не длительность Go или не положительное), используется defaultIdempotencyTTL.
+func getIdempotencyTTL() time.Duration {
+	ttl, err := time.ParseDuration(config.IdempotencyTTL)
+	if err != nil || ttl <= 0 {
+		return defaultIdempotencyTTL
+	}
+	return ttl
+}
+
+// applyDefaultComment подставляет комментарий из переменной окружения TODO_DEFAULT_COMMENT,
+// если клиент не передал свой комментарий. Переданный комментарий не заменяется.
+func applyDefaultComment(task *db.Task) {
+	if task.Comment == "" {
+		task.Comment = config.DefaultComment
+	}
+}
+
 // Функция проверяет и корректирует дату задачи.
 // Параметры:
 // task - указатель на структуру задачи, поле Date которой подлежит проверке и корректировке.
@@ -47,7 +74,8 @@ func checkDate(task *db.Task) error {
 		}
 	}
 
-	return nil
+	// Задачи на выходные отклоняются или переносятся, если это настроено (TODO_NO_WEEKEND_TASKS)
+	return checkWeekend(task)
 }
 
 // Метод обработчика HTTP-запроса для добавления новой задачи.
@@ -66,10 +94,19 @@ func (s *APIServer) addTaskHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Читаем тело запроса, отклоняя некорректный UTF-8 до сохранения в БД
+	body, err := readUTF8Body(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	var task db.Task
 
 	// Декодируем JSON из тела запроса в структуру задачи
-	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
+	if err := json.Unmarshal(body, &task); err != nil {
 		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
 			"error": "invalid JSON payload",
 		})
@@ -77,27 +114,84 @@ func (s *APIServer) addTaskHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Проверяем, что поле Title не пустое (обязательное поле)
-	if task.Title == "" {
+	// Проверяем, что текст задачи - корректный UTF-8
+	if err := checkTaskText(&task); err != nil {
 		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
-			"error": "title cannot be empty",
+			"error": err.Error(),
 		})
+		return
+	}
+
+	// Проверяем, что поле Title не пустое (обязательное поле)
+	if task.Title == "" {
+		api.WriteValidationError(w, "title cannot be empty")
 		// Завершаем обработку, так как Title обязателен
 		return
 	}
 
+	// Проверяем цвет метки задачи
+	if err := checkColor(&task); err != nil {
+		api.WriteValidationError(w, err.Error())
+		return
+	}
+
+	// Проверяем оценку длительности задачи
+	if err := checkDuration(&task); err != nil {
+		api.WriteValidationError(w, err.Error())
+		return
+	}
+
 	// Проверяем и корректируем дату задачи согласно бизнес‑логике
 	if err := checkDate(&task); err != nil {
-		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
-			"error": err.Error(),
-		})
+		api.WriteValidationError(w, err.Error())
 		// Завершаем обработку при ошибке валидации даты
 		return
 	}
 
-	// Сохраняем задачу в базу данных через функцию AddTask
-	id, err := db.AddTask(s.DB, &task)
+	// Для задачи без комментария используем комментарий по умолчанию (если он настроен)
+	applyDefaultComment(&task)
+
+	var (
+		created *db.Task
+		stored  []byte
+	)
+	// Если клиент передал ключ идемпотентности, повторный запрос с тем же ключом
+	// не создаёт новую задачу, а возвращает сохранённый ответ на первый запрос без изменений
+	if key := strings.TrimSpace(r.Header.Get("Idempotency-Key")); key != "" {
+		hash := sha256.Sum256(body)
+		var result db.IdempotentResult
+		result, err = db.AddTaskIdempotent(s.DB, &task, key, hex.EncodeToString(hash[:]), getIdempotencyTTL(), encodeTaskCreated)
+		if err == nil {
+			stored = result.Response
+			if len(stored) == 0 {
+				// Ключ сохранён без ответа (до появления колонки response) - отвечаем по текущему состоянию задачи
+				created, err = db.GetTask(s.DB, strconv.FormatInt(result.TaskID, 10))
+			}
+		}
+	} else {
+		// Сохраняем задачу в базу данных и получаем её вместе с присвоенным ID
+		created, err = db.AddTaskReturning(s.DB, &task)
+	}
 	if err != nil {
+		// Нарушение ограничения целостности (например, уникального индекса) - возвращаем 409 (Conflict)
+		if errors.Is(err, db.ErrConflict) {
+			api.WriteJSON(w, http.StatusConflict, map[string]string{
+				"error": "task conflicts with an existing task",
+			})
+			return
+		}
+		// Ключ идемпотентности уже использован с другим телом запроса - возвращаем 422
+		if errors.Is(err, db.ErrIdempotencyKeyReused) {
+			api.WriteValidationError(w, "Idempotency-Key was already used with a different request body")
+			return
+		}
+		// Задача, созданная ранее по этому ключу идемпотентности, уже удалена - возвращаем 410 (Gone)
+		if errors.Is(err, db.ErrTaskNotFound) {
+			api.WriteJSON(w, http.StatusGone, map[string]string{
+				"error": "task created with this idempotency key has been deleted",
+			})
+			return
+		}
 		log.Printf("failed to save task: %v, task data: %+v", err, task)
 		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
 			"error": "failed to save task",
@@ -106,13 +200,33 @@ func (s *APIServer) addTaskHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Формируем успешный ответ:
-	// - id: идентификатор созданной задачи
-	// - location: URL для доступа к задаче
-	// - message: текстовое подтверждение создания
-	api.WriteJSON(w, http.StatusCreated, map[string]interface{}{
-		"id":       id,
-		"location": fmt.Sprintf("/tasks/%d", id),
+	if stored != nil {
+		api.WriteRawJSON(w, http.StatusCreated, stored)
+		return
+	}
+	api.WriteJSON(w, http.StatusCreated, taskCreatedResponse(created))
+}
+
+// taskCreatedResponse формирует успешный ответ на создание задачи:
+// - id: идентификатор созданной задачи (числом, как и раньше)
+// - location: URL для доступа к задаче
+// - message: текстовое подтверждение создания
+// - task: созданная задача целиком (с датой после корректировки)
+func taskCreatedResponse(created *db.Task) map[string]interface{} {
+	return map[string]interface{}{
+		"id":       json.Number(created.ID),
+		"location": fmt.Sprintf("/tasks/%s", created.ID),
 		"message":  "Task created successfully",
-	})
+		"task":     created,
+	}
+}
+
+// encodeTaskCreated кодирует ответ на создание задачи так же, как api.WriteJSON,
+// чтобы сохранённый с ключом идемпотентности ответ совпадал с обычным.
+func encodeTaskCreated(created *db.Task) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(taskCreatedResponse(created)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
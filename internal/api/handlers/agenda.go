@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"fmt"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+)
+
+const (
+	// agendaTaskLimit - максимальное количество задач, рассматриваемых при построении повестки.
+	agendaTaskLimit = 1000
+	// agendaEntryLimit - максимальное количество записей в повестке (защита от слишком длинной развёртки).
+	agendaEntryLimit = 1000
+)
+
+// AgendaResp - структура для ответа API повестки.
+// Agenda - повторения задач в диапазоне, отсортированные по дате;
+// Truncated - признак того, что повестка обрезана по agendaEntryLimit.
+type AgendaResp struct {
+	Agenda    []*db.Task `json:"agenda"`
+	Truncated bool       `json:"truncated"`
+}
+
+// parseDateRange разбирает параметры from и to (в формате scheduler.DateFormat) из строки запроса.
+// Возвращает границы диапазона и ошибку, если параметры отсутствуют, некорректны или from > to.
+func parseDateRange(r *http.Request) (time.Time, time.Time, error) {
+	from, err := time.Parse(scheduler.DateFormat, r.URL.Query().Get("from"))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid 'from' date: expected format %s", scheduler.DateFormat)
+	}
+	to, err := time.Parse(scheduler.DateFormat, r.URL.Query().Get("to"))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid 'to' date: expected format %s", scheduler.DateFormat)
+	}
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("'from' must not be after 'to'")
+	}
+	return from, to, nil
+}
+
+// agendaHandler - обработчик HTTP-запроса повестки за период.
+// Каждая повторяющаяся задача разворачивается в отдельные записи для всех её повторений в диапазоне [from, to],
+// разовые задачи попадают в повестку, если их дата входит в диапазон.
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса с параметрами from и to.
+func (s *APIServer) agendaHandler(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Задачи с датой позже конца диапазона в повестку попасть не могут
+	tasks, err := db.GetTasksDueBy(s.DB, to.Format(scheduler.DateFormat), agendaTaskLimit)
+	if err != nil {
+		log.Printf("failed to fetch tasks for agenda: %v", err)
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to fetch tasks from database",
+		})
+		return
+	}
+
+	resp := AgendaResp{Agenda: []*db.Task{}}
+	for _, task := range tasks {
+		dates, err := scheduler.Occurrences(task.Date, task.Repeat, from, to, agendaEntryLimit)
+		if err != nil {
+			// Задачи с некорректной датой или правилом пропускаем, не прерывая построение повестки
+			log.Printf("skipping task %s in agenda: %v", task.ID, err)
+			continue
+		}
+		// Каждое повторение - отдельная запись с датой повторения
+		for _, date := range dates {
+			entry := *task
+			entry.Date = date
+			resp.Agenda = append(resp.Agenda, &entry)
+		}
+	}
+
+	// Сортируем по дате; для одинаковых дат сохраняем порядок задач
+	sort.SliceStable(resp.Agenda, func(i, j int) bool {
+		return resp.Agenda[i].Date < resp.Agenda[j].Date
+	})
+	if len(resp.Agenda) > agendaEntryLimit {
+		resp.Agenda = resp.Agenda[:agendaEntryLimit]
+		resp.Truncated = true
+	}
+
+	api.WriteJSON(w, http.StatusOK, resp)
+}
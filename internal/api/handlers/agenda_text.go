@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"fmt"
+	"go-task-manager-final_project/internal/api"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// agendaTextHandler - обработчик HTTP-запроса повестки на день в текстовом виде (для терминала и CLI-утилит).
+// Параметр date (YYYYMMDD) задаёт день; по умолчанию - сегодня.
+// Каждая задача выводится отдельной строкой: дата, заголовок и правило повторения в квадратных скобках (если задано).
+// Ошибки возвращаются в формате JSON, как и у остальных эндпоинтов.
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса.
+func (s *APIServer) agendaTextHandler(w http.ResponseWriter, r *http.Request) {
+	start, err := parseDayParam(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	tasks, err := s.dueTasksOn(start)
+	if err != nil {
+		log.Printf("failed to fetch tasks for text agenda: %v", err)
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to fetch tasks from database",
+		})
+		return
+	}
+
+	var b strings.Builder
+	for _, task := range tasks {
+		// Переводы строк в заголовке заменяем пробелами: одна задача - одна строка
+		title := strings.Join(strings.Fields(task.Title), " ")
+		fmt.Fprintf(&b, "%s  %s", task.Date, title)
+		if task.Repeat != "" {
+			fmt.Fprintf(&b, "  [%s]", task.Repeat)
+		}
+		b.WriteString("\n")
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(b.String()))
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"log"
+	"net/http"
+	"time"
+)
+
+// AnnotatedTask - задача с вычисляемыми полями для отображения в списке.
+// DaysOverdue - на сколько дней просрочена задача (0 для сегодняшних и будущих).
+type AnnotatedTask struct {
+	*db.Task
+	DaysOverdue int `json:"days_overdue"`
+}
+
+// AnnotatedTasksResp - структура ответа со списком задач с вычисляемыми полями.
+type AnnotatedTasksResp struct {
+	Tasks []*AnnotatedTask `json:"tasks"`
+}
+
+// annotatedTasksHandler - обработчик HTTP-запроса для получения списка задач с количеством дней просрочки.
+// "Сегодня" определяется в локальном часовом поясе сервера (переменная окружения TZ).
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса.
+func (s *APIServer) annotatedTasksHandler(w http.ResponseWriter, r *http.Request) {
+	// Получаем формат дат для ответа (параметр date_format)
+	dateFormat, err := responseDateFormat(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	tasks, err := db.GetTasks(s.DB, limit, 0)
+	if err != nil {
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to fetch tasks from database",
+		})
+		return
+	}
+
+	now := time.Now()
+	resp := AnnotatedTasksResp{Tasks: make([]*AnnotatedTask, 0, len(tasks))}
+	for _, task := range tasks {
+		// Просрочка считается по хранимой дате, до перевода в формат ответа
+		days, err := scheduler.DaysOverdue(task.Date, now)
+		if err != nil {
+			log.Printf("failed to compute overdue days for task %s: %v", task.ID, err)
+		}
+		resp.Tasks = append(resp.Tasks, &AnnotatedTask{Task: task, DaysOverdue: days})
+	}
+	formatTaskDates(dateFormat, tasks...)
+
+	api.WriteJSON(w, http.StatusOK, resp)
+}
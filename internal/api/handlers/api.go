@@ -2,9 +2,23 @@ package handlers
 
 import (
 	"database/sql"
+	"go-task-manager-final_project/config"
 	"go-task-manager-final_project/internal/api/middleware"
+	"go-task-manager-final_project/internal/scheduler"
+	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// defaultAPIPrefix - базовый путь API‑эндпоинтов по умолчанию.
+const defaultAPIPrefix = "/api"
+
+// Типы содержимого, которые отдают API‑эндпоинты.
+const (
+	mediaTypeJSON = "application/json"
+	mediaTypeText = "text/plain"
 )
 
 // APIServer представляет собой структуру сервера API, содержащую подключение к базе данных.
@@ -12,47 +26,216 @@ type APIServer struct {
 	DB *sql.DB
 }
 
+// GetAPIPrefix возвращает базовый путь API из переменной окружения TODO_API_PREFIX или значение по умолчанию.
+// Нормализует значение: добавляет ведущий "/" и убирает завершающий.
+// Возвращает: строку - базовый путь API (например, "/api" или "/scheduler").
+func GetAPIPrefix() string {
+	prefix := strings.Trim(strings.TrimSpace(config.APIPrefix), "/")
+	if prefix == "" {
+		// Если переменная окружения не задана, используем путь по умолчанию
+		return defaultAPIPrefix
+	}
+	return "/" + prefix
+}
+
 // Init настраивает роутинг для HTTP‑сервера.
+// Эндпоинты, изменяющие задачи, в режиме "только чтение" отвечают 503 (Service Unavailable).
 // Параметры:
 // r — роутер chi.Mux для регистрации эндпоинтов;
 // db — подключение к базе данных SQL.
-// Регистрирует обработчик для статических файлов и API‑эндпоинты, включая аутентифицированные маршруты для работы с задачами.
+// Регистрирует API‑эндпоинты под базовым путём GetAPIPrefix(), включая аутентифицированные маршруты для работы с задачами.
 func Init(r *chi.Mux, db *sql.DB) {
 
 	server := &APIServer{
 		DB: db,
 	}
 
-	// Регистрируем обработчик API‑эндпоинта для вычисления следующей даты.
-	// Метод: GET. Путь: http://localhost:7540/api/nextdate.
-	r.Get("/api/nextdate", handleNextDay)
+	// Начальное состояние режима "только чтение" берём из переменной окружения TODO_READ_ONLY
+	readOnly, _ := strconv.ParseBool(config.ReadOnly)
+	middleware.SetReadOnly(readOnly)
+
+	// Поведение правила "m" для дней, которых нет в месяце, берём из переменной окружения TODO_MONTH_DAY_CLAMP
+	monthDayClamp, _ := strconv.ParseBool(config.MonthDayClamp)
+	scheduler.SetMonthDayClamp(monthDayClamp)
+
+	// Все API‑эндпоинты регистрируются под общим базовым путём (по умолчанию "/api").
+	r.Route(GetAPIPrefix(), func(r chi.Router) {
+		// Завершающий слэш в пути API игнорируется: /api/tasks/ обслуживается так же, как /api/tasks.
+		// Путь исправляется внутри сервера, без перенаправления, поэтому работает и для POST/PUT/DELETE.
+		r.Use(chimiddleware.StripSlashes)
+
+		// Регистрируем обработчик API‑эндпоинта для вычисления следующей даты.
+		// Метод: GET. Путь: http://localhost:7540/api/nextdate.
+		r.With(middleware.Accept(mediaTypeText, mediaTypeJSON)).Get("/nextdate", handleNextDay)
+
+		// Регистрируем защищённый эндпоинт для получения повестки на день в текстовом виде.
+		// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/agenda.txt.
+		r.With(middleware.Accept(mediaTypeText)).Get("/agenda.txt", middleware.Auth(server.agendaTextHandler))
+
+		// Остальные эндпоинты отвечают только в формате JSON.
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.Accept(mediaTypeJSON))
+
+			// Регистрируем обработчик проверки живости процесса (не обращается к БД).
+			// Метод: GET. Путь: http://localhost:7540/api/ping.
+			r.Get("/ping", pingHandler)
+
+			// Регистрируем обработчик для получения описания правила повторения.
+			// Метод: GET. Путь: http://localhost:7540/api/repeat/describe.
+			r.Get("/repeat/describe", describeRepeatHandler)
+
+			// Регистрируем обработчик для проверки даты по правилу повторения (с пояснением несовпадения).
+			// Метод: GET. Путь: http://localhost:7540/api/repeat/check.
+			r.Get("/repeat/check", checkRepeatHandler)
+
+			// Регистрируем обработчик для проверки и нормализации даты.
+			// Метод: GET. Путь: http://localhost:7540/api/date/normalize.
+			r.Get("/date/normalize", normalizeDateHandler)
+
+			// Регистрируем обработчик для аутентификации пользователя.
+			// Метод: POST. Путь: http://localhost:7540/api/signin.
+			r.Post("/signin", handleSignIn)
+
+			// Регистрируем обработчик для выхода пользователя (сброс cookie с токеном).
+			// Метод: POST. Путь: http://localhost:7540/api/signout.
+			r.Post("/signout", handleSignOut)
+
+			// Регистрируем защищённый эндпоинт для обновления JWT-токена (продления сессии).
+			// Требуется аутентификация. Метод: POST. Путь: http://localhost:7540/api/refresh.
+			r.Post("/refresh", middleware.Auth(refreshHandler))
+
+			// Регистрируем защищённый эндпоинт для получения сведений о текущей сессии.
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/session.
+			r.Get("/session", middleware.Auth(sessionHandler))
+
+			// Регистрируем защищённый эндпоинт для получения списка задач.
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/tasks.
+			r.Get("/tasks", middleware.Auth(server.tasksHandler))
+
+			// Регистрируем защищённый эндпоинт для получения задач, сгруппированных по срокам.
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/tasks/grouped.
+			r.Get("/tasks/grouped", middleware.Auth(server.groupedTasksHandler))
+
+			// Регистрируем защищённый эндпоинт для получения задач за год, сгруппированных по месяцам.
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/tasks/by-month.
+			r.Get("/tasks/by-month", middleware.Auth(server.tasksByMonthHandler))
+
+			// Регистрируем защищённый эндпоинт для получения списка архивных задач.
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/tasks/archived.
+			r.Get("/tasks/archived", middleware.Auth(server.archivedTasksHandler))
+
+			// Регистрируем защищённый эндпоинт для получения списка задач с количеством дней просрочки.
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/tasks/annotated.
+			r.Get("/tasks/annotated", middleware.Auth(server.annotatedTasksHandler))
+
+			// Регистрируем защищённый эндпоинт для переноса всех просроченных задач на сегодня.
+			// Требуется аутентификация. Метод: POST. Путь: http://localhost:7540/api/tasks/reschedule-overdue.
+			r.Post("/tasks/reschedule-overdue", middleware.Auth(middleware.ReadOnly(server.rescheduleOverdueHandler)))
+
+			// Регистрируем защищённый эндпоинт для назначения правила повторения нескольким задачам.
+			// Требуется аутентификация. Метод: POST. Путь: http://localhost:7540/api/tasks/set-repeat.
+			r.Post("/tasks/set-repeat", middleware.Auth(middleware.ReadOnly(server.setRepeatHandler)))
+
+			// Регистрируем защищённый эндпоинт для удаления выполненных (прошедших разовых) задач с подтверждением.
+			// Требуется аутентификация. Метод: POST. Путь: http://localhost:7540/api/tasks/clear-completed.
+			r.Post("/tasks/clear-completed", middleware.Auth(middleware.ReadOnly(server.clearCompletedHandler)))
+
+			// Регистрируем защищённый эндпоинт для получения повестки за период с развёрткой повторений.
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/agenda.
+			r.Get("/agenda", middleware.Auth(server.agendaHandler))
+
+			// Регистрируем защищённый эндпоинт для получения ежедневной сводки (запланированные и выполненные задачи).
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/digest.
+			r.Get("/digest", middleware.Auth(server.digestHandler))
+
+			// Регистрируем защищённый эндпоинт для получения суммарной оценки длительности задач на сегодня.
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/stats/duration.
+			r.Get("/stats/duration", middleware.Auth(server.durationStatsHandler))
+
+			// Регистрируем защищённый эндпоинт для добавления новой задачи.
+			// Требуется аутентификация. Метод: POST. Путь: http://localhost:7540/api/task.
+			r.Post("/task", middleware.Auth(middleware.ReadOnly(server.addTaskHandler)))
+
+			// Регистрируем защищённый эндпоинт для создания копий задачи на несколько дат.
+			// Требуется аутентификация. Метод: POST. Путь: http://localhost:7540/api/task/spread.
+			r.Post("/task/spread", middleware.Auth(middleware.ReadOnly(server.spreadTaskHandler)))
+
+			// Регистрируем защищённый эндпоинт для отметки задачи как выполненной.
+			// Требуется аутентификация. Метод: POST. Путь: http://localhost:7540/api/task/done.
+			r.Post("/task/done", middleware.Auth(middleware.ReadOnly(server.doneTaskHandler)))
+
+			// Регистрируем защищённый эндпоинт для переноса задачи на ближайший указанный день недели.
+			// Требуется аутентификация. Метод: POST. Путь: http://localhost:7540/api/task/snooze-weekday.
+			r.Post("/task/snooze-weekday", middleware.Auth(middleware.ReadOnly(server.snoozeWeekdayHandler)))
+
+			// Регистрируем защищённые эндпоинты для переноса задачи в архив и возврата из архива.
+			// Требуется аутентификация. Метод: POST. Пути: http://localhost:7540/api/task/archive, http://localhost:7540/api/task/unarchive.
+			r.Post("/task/archive", middleware.Auth(middleware.ReadOnly(server.archiveTaskHandler)))
+			r.Post("/task/unarchive", middleware.Auth(middleware.ReadOnly(server.unarchiveTaskHandler)))
+
+			// Регистрируем защищённый эндпоинт для получения серий своевременного выполнения задачи.
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/task/streak.
+			r.Get("/task/streak", middleware.Auth(server.streakHandler))
+
+			// Регистрируем защищённый эндпоинт для получения ближайшей даты задачи без её изменения.
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/task/next-date.
+			r.Get("/task/next-date", middleware.Auth(server.taskNextDateHandler))
+
+			// Регистрируем защищённый эндпоинт для получения предыдущего (последнего наступившего) повторения задачи.
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/task/prev-date.
+			r.Get("/task/prev-date", middleware.Auth(server.taskPrevDateHandler))
+
+			// Регистрируем защищённый эндпоинт для подсчёта повторений задачи в диапазоне дат.
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/task/occurrence-count.
+			r.Get("/task/occurrence-count", middleware.Auth(server.occurrenceCountHandler))
+
+			// Регистрируем защищённый эндпоинт для получения конкретной задачи.
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/task.
+			r.Get("/task", middleware.Auth(server.getTaskHandler))
+
+			// Регистрируем защищённый эндпоинт для обновления задачи.
+			// Требуется аутентификация. Метод: PUT. Путь: http://localhost:7540/api/task.
+			r.Put("/task", middleware.Auth(middleware.ReadOnly(server.putTaskHandler)))
+
+			// Регистрируем защищённый эндпоинт для частичного обновления задачи (только переданные поля).
+			// Требуется аутентификация. Метод: PATCH. Путь: http://localhost:7540/api/task.
+			r.Patch("/task", middleware.Auth(middleware.ReadOnly(server.patchTaskHandler)))
+
+			// Регистрируем защищённый эндпоинт для удаления задачи.
+			// Требуется аутентификация. Метод: DELETE. Путь: http://localhost:7540/api/task.
+			r.Delete("/task", middleware.Auth(middleware.ReadOnly(server.deleteTaskHandler)))
 
-	// Регистрируем обработчик для аутентификации пользователя.
-	// Метод: POST. Путь: http://localhost:7540/api/signin.
-	r.Post("/api/signin", handleSignIn)
+			// Регистрируем защищённые эндпоинты для получения, обновления и удаления задачи с id в пути
+			// (аналоги /api/task?id=...). Требуется аутентификация. Методы: GET, PUT, DELETE. Путь: http://localhost:7540/api/task/{id}.
+			r.Get("/task/{id}", middleware.Auth(server.getTaskHandler))
+			r.Put("/task/{id}", middleware.Auth(middleware.ReadOnly(server.putTaskHandler)))
+			r.Delete("/task/{id}", middleware.Auth(middleware.ReadOnly(server.deleteTaskHandler)))
 
-	// Регистрируем защищённый эндпоинт для получения списка задач.
-	// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/tasks.
-	r.Get("/api/tasks", middleware.Auth(server.tasksHandler))
+			// Регистрируем защищённый эндпоинт для выгрузки всех задач (потоковой, без буферизации в памяти).
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/export.
+			r.Get("/export", middleware.Auth(server.exportHandler))
 
-	// Регистрируем защищённый эндпоинт для добавления новой задачи.
-	// Требуется аутентификация. Метод: POST. Путь: http://localhost:7540/api/task.
-	r.Post("/api/task", middleware.Auth(server.addTaskHandler))
+			// Регистрируем защищённый эндпоинт для импорта задач (в формате выгрузки /api/export).
+			// Требуется аутентификация. Метод: POST. Путь: http://localhost:7540/api/import.
+			r.Post("/import", middleware.Auth(middleware.ReadOnly(server.importHandler)))
 
-	// Регистрируем защищённый эндпоинт для отметки задачи как выполненной.
-	// Требуется аутентификация. Метод: POST. Путь: http://localhost:7540/api/task/done.
-	r.Post("/api/task/done", middleware.Auth(server.doneTaskHandler))
+			// Регистрируем защищённый эндпоинт для предварительной проверки задач перед импортом.
+			// Требуется аутентификация. Метод: POST. Путь: http://localhost:7540/api/import/validate.
+			r.Post("/import/validate", middleware.Auth(importValidateHandler))
 
-	// Регистрируем защищённый эндпоинт для получения конкретной задачи.
-	// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/task.
-	r.Get("/api/task", middleware.Auth(server.getTaskHandler))
+			// Регистрируем защищённые эндпоинты для получения и переключения режима "только чтение".
+			// Требуется аутентификация. Методы: GET, POST. Путь: http://localhost:7540/api/admin/read-only.
+			r.Get("/admin/read-only", middleware.Auth(readOnlyHandler))
+			r.Post("/admin/read-only", middleware.Auth(readOnlyHandler))
 
-	// Регистрируем защищённый эндпоинт для обновления задачи.
-	// Требуется аутентификация. Метод: PUT. Путь: http://localhost:7540/api/task.
-	r.Put("/api/task", middleware.Auth(server.putTaskHandler))
+			// Регистрируем защищённый эндпоинт для получения размера БД и количества задач, доступен при TODO_ADMIN_ENDPOINTS=true.
+			// Требуется аутентификация. Метод: GET. Путь: http://localhost:7540/api/admin/db-size.
+			r.Get("/admin/db-size", middleware.Auth(adminOnly(server.dbSizeHandler)))
 
-	// Регистрируем защищённый эндпоинт для удаления задачи.
-	// Требуется аутентификация. Метод: DELETE. Путь: http://localhost:7540/api/task.
-	r.Delete("/api/task", middleware.Auth(server.deleteTaskHandler))
+			// Регистрируем защищённый эндпоинт для сжатия файла БД (VACUUM), доступен при TODO_ADMIN_ENDPOINTS=true.
+			// Требуется аутентификация. Метод: POST. Путь: http://localhost:7540/api/admin/vacuum.
+			r.Post("/admin/vacuum", middleware.Auth(adminOnly(middleware.ReadOnly(server.vacuumHandler))))
+		})
+	})
 
 }
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// archiveTaskHandler переносит задачу в архив: она пропадает из основного списка, но не удаляется.
+// Параметры:
+// w - http.ResponseWriter для отправки ответа клиенту;
+// r - *http.Request, входящий HTTP-запрос с параметром id.
+func (s *APIServer) archiveTaskHandler(w http.ResponseWriter, r *http.Request) {
+	s.setArchived(w, r, true)
+}
+
+// unarchiveTaskHandler возвращает задачу из архива в основной список.
+// Параметры:
+// w - http.ResponseWriter для отправки ответа клиенту;
+// r - *http.Request, входящий HTTP-запрос с параметром id.
+func (s *APIServer) unarchiveTaskHandler(w http.ResponseWriter, r *http.Request) {
+	s.setArchived(w, r, false)
+}
+
+// setArchived проверяет параметр id и устанавливает признак архивной задачи.
+func (s *APIServer) setArchived(w http.ResponseWriter, r *http.Request, archived bool) {
+	// Получаем параметр id из строки запроса
+	id := r.URL.Query().Get("id")
+
+	// Проверяем, что ID не пустой и не состоит только из пробелов
+	if strings.TrimSpace(id) == "" {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "id parameter required",
+		})
+		return
+	}
+
+	// Проверяем формат ID (числовой)
+	if _, err := strconv.Atoi(id); err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid id format: must be a integer number",
+		})
+		return
+	}
+
+	// Сначала убеждаемся, что задача существует
+	if _, err := db.GetTask(s.DB, id); err != nil {
+		if errors.Is(err, db.ErrTaskNotFound) {
+			api.WriteJSON(w, http.StatusNotFound, map[string]string{
+				"error": "task not found",
+			})
+			return
+		}
+		writeInternalError(w, "could not retrieve task", err)
+		return
+	}
+
+	if err := db.SetArchived(s.DB, id, archived); err != nil {
+		log.Printf("failed to update archived flag for task %s: %v", id, err)
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "could not update task",
+		})
+		return
+	}
+
+	// Успешное обновление - возвращаем 200 (OK) с пустым JSON-объектом
+	api.WriteJSON(w, http.StatusOK, map[string]interface{}{})
+}
+
+// archivedTasksHandler - обработчик HTTP-запроса для получения списка архивных задач.
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса.
+func (s *APIServer) archivedTasksHandler(w http.ResponseWriter, r *http.Request) {
+	// Получаем формат дат для ответа (параметр date_format)
+	dateFormat, err := responseDateFormat(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	tasks, err := db.GetArchivedTasks(s.DB, limit)
+	if err != nil {
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to fetch tasks from database",
+		})
+		return
+	}
+
+	// Если задач нет - возвращаем пустой массив, а не null
+	if tasks == nil {
+		tasks = []*db.Task{}
+	}
+	formatTaskDates(dateFormat, tasks...)
+
+	api.WriteJSON(w, http.StatusOK, TasksResp{
+		Tasks: tasks,
+	})
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/scheduler"
+	"net/http"
+)
+
+// CheckRepeatResp - результат проверки даты по правилу повторения.
+type CheckRepeatResp struct {
+	Matches     bool   `json:"matches"`
+	Explanation string `json:"explanation"`
+}
+
+// checkRepeatHandler обрабатывает HTTP‑запрос на проверку, является ли дата повторением по правилу.
+// Ожидает GET‑запрос с параметрами:
+// - date (проверяемая дата в формате 20060102);
+// - rule (правило повторения, например "w 1,3,5");
+// - start (стартовая дата задачи, обязательна для правил "d" и "y").
+// Возвращает JSON вида {"matches": false, "explanation": "..."} (см. scheduler.CheckRepeat)
+// или ошибку 400 для некорректной даты или правила.
+func checkRepeatHandler(w http.ResponseWriter, r *http.Request) {
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "date parameter required",
+		})
+		return
+	}
+
+	matches, explanation, err := scheduler.CheckRepeat(date, r.URL.Query().Get("start"), r.URL.Query().Get("rule"))
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, CheckRepeatResp{
+		Matches:     matches,
+		Explanation: explanation,
+	})
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+)
+
+// ClearCompletedPreviewResp - ответ предварительного просмотра очистки выполненных задач.
+// Confirm передаётся в параметре confirm повторного запроса для подтверждения удаления.
+type ClearCompletedPreviewResp struct {
+	WouldDelete int    `json:"would_delete"`
+	Confirm     string `json:"confirm"`
+}
+
+// clearCompletedToken вычисляет токен подтверждения для набора выполненных задач.
+// Токен не хранится на сервере: он меняется вместе с набором задач, поэтому устаревший токен
+// (после выполнения, изменения или удаления задач) не совпадёт с текущим.
+func clearCompletedToken(ids []int64) string {
+	hash := sha256.New()
+	for _, id := range ids {
+		hash.Write([]byte("," + strconv.FormatInt(id, 10)))
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// clearCompletedHandler удаляет выполненные задачи - задачи в состоянии done (см. db.CompletedTaskIDs).
+// Удаление выполняется в два шага: запрос без параметра confirm возвращает предварительный просмотр
+// {"would_delete": N, "confirm": "<токен>"}, и только повторный запрос с этим токеном удаляет задачи.
+// Если набор задач с момента просмотра изменился, токен считается устаревшим и возвращается 409.
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса.
+func (s *APIServer) clearCompletedHandler(w http.ResponseWriter, r *http.Request) {
+	ids, err := db.CompletedTaskIDs(s.DB)
+	if err != nil {
+		writeInternalError(w, "could not fetch completed tasks", err)
+		return
+	}
+	token := clearCompletedToken(ids)
+
+	// Без токена подтверждения только сообщаем, сколько задач будет удалено
+	confirm := r.URL.Query().Get("confirm")
+	if confirm == "" {
+		api.WriteJSON(w, http.StatusOK, ClearCompletedPreviewResp{
+			WouldDelete: len(ids),
+			Confirm:     token,
+		})
+		return
+	}
+
+	if confirm != token {
+		api.WriteJSON(w, http.StatusConflict, map[string]string{
+			"error": "confirm token is invalid or stale, request a new preview",
+		})
+		return
+	}
+
+	deleted, err := db.ClearCompleted(s.DB, ids)
+	if err != nil {
+		// Набор задач изменился между проверкой токена и удалением
+		if errors.Is(err, db.ErrCompletedChanged) {
+			api.WriteJSON(w, http.StatusConflict, map[string]string{
+				"error": "confirm token is invalid or stale, request a new preview",
+			})
+			return
+		}
+		writeInternalError(w, "could not clear completed tasks", err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, map[string]int64{
+		"deleted": deleted,
+	})
+}
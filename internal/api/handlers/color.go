@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"fmt"
+	"go-task-manager-final_project/internal/db"
+	"regexp"
+	"strings"
+)
+
+// colorHexPattern - цвет в шестнадцатеричном виде #RRGGBB.
+var colorHexPattern = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+// colorPalette - названия цветов, допустимые вместо шестнадцатеричного кода.
+var colorPalette = map[string]bool{
+	"red":    true,
+	"orange": true,
+	"yellow": true,
+	"green":  true,
+	"blue":   true,
+	"purple": true,
+	"pink":   true,
+	"gray":   true,
+}
+
+// checkColor проверяет и нормализует цвет метки задачи (приводит к нижнему регистру).
+// Пустой цвет допустим - задача без метки.
+// Параметры:
+// task - задача, поле Color которой проверяется.
+// Возвращает: ошибку, если цвет не является кодом #RRGGBB или названием из палитры.
+func checkColor(task *db.Task) error {
+	color := strings.ToLower(strings.TrimSpace(task.Color))
+	if color != "" && !colorHexPattern.MatchString(color) && !colorPalette[color] {
+		return fmt.Errorf("invalid color %q: expected #RRGGBB or one of red, orange, yellow, green, blue, purple, pink, gray", task.Color)
+	}
+	task.Color = color
+	return nil
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"fmt"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"net/http"
+	"time"
+)
+
+// Значения параметра date_format, задающего формат дат в ответах.
+const (
+	dateFormatCompact = "compact" // YYYYMMDD - формат хранения (по умолчанию)
+	dateFormatISO     = "iso"     // YYYY-MM-DD
+)
+
+// responseDateFormat возвращает запрошенный формат дат из параметра date_format.
+// Параметры:
+// r - HTTP-запрос.
+// Возвращает: формат (dateFormatCompact, если параметр не задан) и ошибку для неизвестного значения.
+func responseDateFormat(r *http.Request) (string, error) {
+	switch format := r.URL.Query().Get("date_format"); format {
+	case "", dateFormatCompact:
+		return dateFormatCompact, nil
+	case dateFormatISO:
+		return dateFormatISO, nil
+	default:
+		return "", fmt.Errorf("unsupported date_format %q: expected %q or %q", format, dateFormatISO, dateFormatCompact)
+	}
+}
+
+// formatTaskDates переводит даты задач в запрошенный формат. Хранимые значения не изменяются -
+// преобразование применяется только к задачам, которые будут отправлены в ответе.
+// Даты, которые не удаётся разобрать, остаются без изменений.
+// Параметры:
+// format - формат из responseDateFormat;
+// tasks - задачи для ответа.
+func formatTaskDates(format string, tasks ...*db.Task) {
+	if format != dateFormatISO {
+		return
+	}
+	for _, task := range tasks {
+		if t, err := time.Parse(scheduler.DateFormat, task.Date); err == nil {
+			task.Date = t.Format(scheduler.ISODateFormat)
+		}
+	}
+}
@@ -1,8 +1,7 @@
 package handlers
 
 import (
-	"database/sql"
-	"fmt"
+	"errors"
 	"go-task-manager-final_project/internal/api"
 	"go-task-manager-final_project/internal/db"
 	"strconv"
@@ -16,13 +15,13 @@ import (
 // w - объект для записи HTTP-ответа;
 // r - HTTP-запрос с информацией о запросе (включая параметры URL).
 // Логика:
-//  1. Извлекает параметр id из строки запроса.
+//  1. Извлекает id из пути (/api/task/{id}) или из строки запроса (?id=).
 //  2. Проверяет, что id не пустой.
 //  3. Пытается удалить задачу по указанному id.
 //  4. Возвращает соответствующий HTTP-статус и JSON-ответ в зависимости от результата.
 func (s *APIServer) deleteTaskHandler(w http.ResponseWriter, r *http.Request) {
-	// Получаем параметр id из строки запроса (например, /delete?id=123)
-	id := r.URL.Query().Get("id")
+	// Получаем id из пути или из строки запроса (например, /api/task/123 или /api/task?id=123)
+	id := taskIDParam(r)
 
 	// Проверяем, что ID не пустой и не состоит только из пробелов
 	if strings.TrimSpace(id) == "" {
@@ -43,16 +42,14 @@ func (s *APIServer) deleteTaskHandler(w http.ResponseWriter, r *http.Request) {
 	// Пытаемся удалить задачу с указанным ID из базы данных
 	err := db.DeleteTask(s.DB, id)
 	if err != nil {
-		// Если задача не найдена в БД (стандартная ошибка SQL), возвращаем статус 404 (Not Found)
-		if err == sql.ErrNoRows {
+		// Если задача не найдена в БД (db.ErrTaskNotFound), возвращаем статус 404 (Not Found)
+		if errors.Is(err, db.ErrTaskNotFound) {
 			api.WriteJSON(w, http.StatusNotFound, map[string]string{
 				"error": "task not found in database",
 			})
 		} else {
 			// Любая другая ошибка при удалении (например, проблемы с соединением), возвращаем статус 500 (Internal Server Error)
-			api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
-				"error": fmt.Sprintf("could not delete task: %v", err),
-			})
+			writeInternalError(w, "could not delete task", err)
 		}
 		return
 	}
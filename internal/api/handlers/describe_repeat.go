@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/scheduler"
+	"net/http"
+)
+
+// describeRepeatHandler обрабатывает HTTP‑запрос на получение человекочитаемого описания правила повторения.
+// Ожидает GET‑запрос с параметрами:
+// - rule (правило повторения, например "d 7");
+// - lang (язык описания: "ru" - по умолчанию, или "en").
+// Возвращает JSON вида {"description": "..."} или ошибку 400 для неизвестного правила или языка.
+func describeRepeatHandler(w http.ResponseWriter, r *http.Request) {
+	lang := r.URL.Query().Get("lang")
+	if lang == "" {
+		lang = scheduler.LangRussian
+	}
+
+	description, err := scheduler.DescribeRepeat(r.URL.Query().Get("rule"), lang)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, map[string]string{
+		"description": description,
+	})
+}
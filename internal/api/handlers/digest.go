@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"fmt"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"log"
+	"net/http"
+	"time"
+)
+
+// DigestResp - структура ответа для ежедневной сводки.
+// Date - день сводки (YYYYMMDD);
+// Due - задачи, запланированные на этот день (с учётом повторений);
+// Completed - задачи, выполненные в этот день.
+type DigestResp struct {
+	Date      string              `json:"date"`
+	Due       []*db.Task          `json:"due"`
+	Completed []*db.CompletedTask `json:"completed"`
+}
+
+// parseDayParam разбирает необязательный параметр date (в формате scheduler.DateFormat) из строки запроса.
+// Возвращает начало указанного дня (по умолчанию - сегодняшнего) и ошибку, если дата некорректна.
+func parseDayParam(r *http.Request) (time.Time, error) {
+	day := time.Now()
+	if value := r.URL.Query().Get("date"); value != "" {
+		parsed, err := time.ParseInLocation(scheduler.DateFormat, value, time.Local)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid 'date': expected format %s", scheduler.DateFormat)
+		}
+		day = parsed
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local), nil
+}
+
+// dueTasksOn возвращает задачи, запланированные на день start (с учётом повторений).
+// Дата каждой задачи в результате заменяется датой этого дня.
+// Параметры:
+// start - начало дня.
+// Возвращает список задач (пустой, а не nil) и ошибку чтения из БД.
+func (s *APIServer) dueTasksOn(start time.Time) ([]*db.Task, error) {
+	date := start.Format(scheduler.DateFormat)
+
+	// Задачи с датой позже этого дня на него попасть не могут
+	tasks, err := db.GetTasksDueBy(s.DB, date, agendaTaskLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	due := []*db.Task{}
+	for _, task := range tasks {
+		// Повторяющаяся задача попадает в список, если одно из её повторений приходится на этот день
+		dates, err := scheduler.Occurrences(task.Date, task.Repeat, start, start, 1)
+		if err != nil {
+			log.Printf("skipping task %s due on %s: %v", task.ID, date, err)
+			continue
+		}
+		if len(dates) > 0 {
+			entry := *task
+			entry.Date = date
+			due = append(due, &entry)
+		}
+	}
+	return due, nil
+}
+
+// digestHandler - обработчик HTTP-запроса ежедневной сводки.
+// Параметр date (YYYYMMDD) задаёт день сводки; по умолчанию - сегодня.
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса.
+func (s *APIServer) digestHandler(w http.ResponseWriter, r *http.Request) {
+	start, err := parseDayParam(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+	date := start.Format(scheduler.DateFormat)
+
+	due, err := s.dueTasksOn(start)
+	if err != nil {
+		log.Printf("failed to fetch tasks for digest: %v", err)
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to fetch tasks from database",
+		})
+		return
+	}
+
+	resp := DigestResp{Date: date, Due: due}
+	resp.Completed, err = db.GetCompletedBetween(s.DB, start, start.AddDate(0, 0, 1))
+	if err != nil {
+		log.Printf("failed to fetch completions for digest: %v", err)
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to fetch completions from database",
+		})
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, resp)
+}
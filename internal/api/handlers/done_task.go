@@ -1,11 +1,12 @@
 package handlers
 
 import (
-	"database/sql"
+	"errors"
 	"fmt"
 	"go-task-manager-final_project/internal/api"
 	"go-task-manager-final_project/internal/db"
 	"go-task-manager-final_project/internal/scheduler"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
@@ -13,7 +14,10 @@ import (
 )
 
 // doneTaskHandler обрабатывает запрос на завершение задачи.
-// В зависимости от наличия правила повторения (task.Repeat) либо удаляет задачу, либо вычисляет и устанавливает новую дату выполнения.
+// В зависимости от наличия правила повторения (task.Repeat) либо отмечает разовую задачу выполненной (status=done,
+// задача остаётся в БД), либо вычисляет и устанавливает новую дату выполнения.
+// По умолчанию повторяющаяся задача сдвигается на одно повторение вперёд от своей даты (просроченная на несколько
+// повторений задача остаётся просроченной); при catch_up=true - сразу на первое повторение после сегодняшнего дня.
 // Параметры:
 // w - http.ResponseWriter для отправки ответа клиенту;
 // r - *http.Request, входящий HTTP-запрос.
@@ -37,10 +41,23 @@ func (s *APIServer) doneTaskHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Режим переноса повторяющейся задачи (параметр catch_up)
+	catchUp := false
+	if value := r.URL.Query().Get("catch_up"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+				"error": "catch_up must be a boolean",
+			})
+			return
+		}
+		catchUp = parsed
+	}
+
 	// Пытаемся получить задачу из базы данных по указанному ID
 	task, err := db.GetTask(s.DB, id)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, db.ErrTaskNotFound) {
 			// Задача с таким ID не найдена в БД - возвращаем 404 (Not Found)
 			api.WriteJSON(w, http.StatusNotFound, map[string]string{
 				"error": "task not found",
@@ -55,32 +72,45 @@ func (s *APIServer) doneTaskHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Проверяем наличие правила повторения задачи
-	// Если Repeat пуст - задача не периодическая, её нужно удалить
+	// Если Repeat пуст - задача не периодическая, отмечаем её выполненной
 	if task.Repeat == "" {
-		// Пытаемся удалить задачу из БД
-		err = db.DeleteTask(s.DB, id)
+		// Повторная отметка уже выполненной задачи ничего не меняет
+		if task.Status == db.StatusDone {
+			api.WriteJSON(w, http.StatusOK, map[string]interface{}{})
+			return
+		}
+
+		err = db.UpdateStatus(s.DB, id, db.StatusDone)
 		if err != nil {
-			if err == sql.ErrNoRows {
-				// Задача уже удалена или не существует - возвращаем 404 (Not Found)
+			if errors.Is(err, db.ErrTaskNotFound) {
+				// Задача удалена параллельным запросом - возвращаем 404 (Not Found)
 				api.WriteJSON(w, http.StatusNotFound, map[string]string{
 					"error": "task not found",
 				})
 			} else {
-				// Неожиданная ошибка при удалении - возвращаем 500 (Internal Server Error)
+				// Неожиданная ошибка при обновлении - возвращаем 500 (Internal Server Error)
 				api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
-					"error": "could not delete task",
+					"error": "could not update task status",
 				})
 			}
 			return
 		}
-		// Успешное удаление - возвращаем 200 (OK) с пустым JSON-объектом
+		// Сохраняем отметку о выполнении
+		s.recordCompletion(task)
+
+		// Успешное обновление - возвращаем 200 (OK) с пустым JSON-объектом
 		api.WriteJSON(w, http.StatusOK, map[string]interface{}{})
 		return
 	}
 
-	// Задача периодическая - нужно вычислить следующую дату выполнения
-	// Используем текущую дату, дату задачи и правило повторения
-	next, err := scheduler.NextDate(time.Now(), task.Date, task.Repeat)
+	// Задача периодическая - нужно вычислить следующую дату выполнения.
+	// Следующее повторение отсчитывается от даты задачи (один шаг),
+	// а при catch_up=true - от текущей даты, если она позже даты задачи (пропущенные повторения не учитываются).
+	from, err := time.Parse(scheduler.DateFormat, task.Date)
+	if err != nil || (catchUp && time.Now().After(from)) {
+		from = time.Now()
+	}
+	next, err := scheduler.NextDate(from, task.Date, task.Repeat)
 	if err != nil {
 		// Ошибка при расчёте даты (например, некорректный формат Repeat) - возвращаем 400
 		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
@@ -89,9 +119,24 @@ func (s *APIServer) doneTaskHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Обновляем дату задачи в БД на вычисленную следующую дату
-	err = db.UpdateDate(s.DB, next, id)
+	// Обновляем дату задачи в БД на вычисленную следующую дату, только если она не изменилась с момента чтения:
+	// из параллельных запросов на выполнение одной задачи дату сдвинет только один
+	err = db.AdvanceDate(s.DB, id, task.Date, next)
 	if err != nil {
+		// Задачу уже отметил выполненной (или изменил) другой запрос - возвращаем 409 (Conflict)
+		if errors.Is(err, db.ErrStaleTask) {
+			api.WriteJSON(w, http.StatusConflict, map[string]string{
+				"error": "task was modified concurrently, please retry",
+			})
+			return
+		}
+		// Новая дата нарушает ограничение целостности - возвращаем 409 (Conflict)
+		if errors.Is(err, db.ErrConflict) {
+			api.WriteJSON(w, http.StatusConflict, map[string]string{
+				"error": "task conflicts with an existing task",
+			})
+			return
+		}
 		// Ошибка при обновлении даты в БД - возвращаем 500 (Internal Server Error)
 		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
 			"error": "could not update task date",
@@ -99,6 +144,17 @@ func (s *APIServer) doneTaskHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Сохраняем отметку о выполнении
+	s.recordCompletion(task)
+
 	// Успешное обновление задачи - возвращаем OK с пустым JSON-объектом
 	api.WriteJSON(w, http.StatusOK, map[string]interface{}{})
 }
+
+// recordCompletion сохраняет отметку о выполнении задачи на её текущую плановую дату.
+// История выполнений не критична для отметки задачи, поэтому ошибка только логируется.
+func (s *APIServer) recordCompletion(task *db.Task) {
+	if err := db.AddCompletion(s.DB, task.ID, task.Date, time.Now()); err != nil {
+		log.Printf("failed to record completion for task %s: %v", task.ID, err)
+	}
+}
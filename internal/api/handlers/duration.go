@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+)
+
+// maxDurationMinutes - максимальная оценка длительности задачи (одни сутки).
+const maxDurationMinutes = 24 * 60
+
+// DurationStatsResp - ответ API с суммарной оценкой длительности задач на сегодня.
+type DurationStatsResp struct {
+	Date         string `json:"date"`
+	TotalMinutes int64  `json:"total_minutes"`
+	Tasks        int64  `json:"tasks"`
+}
+
+// checkDuration проверяет оценку длительности задачи.
+// Параметры:
+// task - задача, поле DurationMinutes которой проверяется.
+// Возвращает: ошибку, если длительность выходит за диапазон [0, maxDurationMinutes].
+func checkDuration(task *db.Task) error {
+	if task.DurationMinutes < 0 || task.DurationMinutes > maxDurationMinutes {
+		return fmt.Errorf("duration_minutes must be in range [0, %d]: got %d", maxDurationMinutes, task.DurationMinutes)
+	}
+	return nil
+}
+
+// durationStatsHandler возвращает сумму оценок длительности задач, запланированных на сегодня.
+// Учитываются активные (не архивные) задачи; задачи без оценки дают 0 минут.
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса.
+func (s *APIServer) durationStatsHandler(w http.ResponseWriter, r *http.Request) {
+	today := time.Now().Format(scheduler.DateFormat)
+
+	total, count, err := db.SumDurationDueOn(s.DB, today)
+	if err != nil {
+		writeInternalError(w, "could not compute task durations", err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, DurationStatsResp{
+		Date:         today,
+		TotalMinutes: total,
+		Tasks:        count,
+	})
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-task-manager-final_project/internal/db"
+	"log"
+	"net/http"
+)
+
+// exportFlushEvery - через сколько задач буферизованный ответ выгрузки отправляется клиенту.
+const exportFlushEvery = 100
+
+// exportHandler выгружает все задачи (включая архивные) в виде JSON-массива.
+// Задачи читаются из БД и пишутся в ответ по одной, с периодической отправкой клиенту,
+// поэтому память не зависит от количества задач, а загрузка начинается сразу.
+// Если ошибка возникает после начала выгрузки, она логируется, а соединение разрывается:
+// клиент получает оборванный ответ, а не корректный, но неполный JSON.
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса.
+func (s *APIServer) exportHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="tasks.json"`)
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(true)
+
+	count := 0
+	_, err := w.Write([]byte("["))
+	if err == nil {
+		err = db.EachTask(s.DB, func(task *db.Task) error {
+			if count > 0 {
+				if _, err := w.Write([]byte(",")); err != nil {
+					return err
+				}
+			}
+			if err := encoder.Encode(task); err != nil {
+				return err
+			}
+			count++
+			if flusher != nil && count%exportFlushEvery == 0 {
+				flusher.Flush()
+			}
+			return nil
+		})
+	}
+	if err == nil {
+		_, err = w.Write([]byte("]\n"))
+	}
+	if err != nil {
+		log.Printf("export aborted after %d tasks: %v", count, err)
+		// Статус 200 уже отправлен - разрываем соединение, чтобы клиент не принял неполный ответ за полный
+		panic(http.ErrAbortHandler)
+	}
+}
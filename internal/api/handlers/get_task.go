@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"go-task-manager-final_project/internal/api"
 	"go-task-manager-final_project/internal/db"
 	"net/http"
@@ -13,13 +14,13 @@ import (
 // w - объект для записи HTTP-ответа;
 // r - HTTP-запрос с параметрами.
 // Логика:
-//  1. Извлекает параметр id из запроса.
+//  1. Извлекает id из пути (/api/task/{id}) или из строки запроса (?id=).
 //  2. Проверяет наличие ID.
 //  3. Запрашивает задачу из БД по ID.
 //  4. Возвращает результат (задачу или ошибку).
 func (s *APIServer) getTaskHandler(w http.ResponseWriter, r *http.Request) {
-	// Получаем параметр id из строки запроса
-	id := r.URL.Query().Get("id")
+	// Получаем id из пути или из строки запроса
+	id := taskIDParam(r)
 
 	// Проверяем, что ID не пустой
 	if strings.TrimSpace(id) == "" {
@@ -37,11 +38,20 @@ func (s *APIServer) getTaskHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Получаем формат дат для ответа (параметр date_format)
+	dateFormat, err := responseDateFormat(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	// Вызываем БД для получения задачи по ID
 	task, err := db.GetTask(s.DB, id)
 	if err != nil {
 		// Различаем типы ошибок для более точной обратной связи
-		if err.Error() == "task with id "+id+" not found" {
+		if errors.Is(err, db.ErrTaskNotFound) {
 			api.WriteJSON(w, http.StatusNotFound, map[string]string{
 				"error": "task not found",
 			})
@@ -54,6 +64,9 @@ func (s *APIServer) getTaskHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Приводим дату к запрошенному формату
+	formatTaskDates(dateFormat, task)
+
 	// Формируем успешный ответ с найденной задачей
 	// Статус: HTTP 200 OK
 	// Тело ответа: объект задачи в JSON-формате.
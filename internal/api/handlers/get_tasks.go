@@ -1,11 +1,17 @@
 package handlers
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-task-manager-final_project/config"
 	"go-task-manager-final_project/internal/api"
 	"go-task-manager-final_project/internal/db"
 	"go-task-manager-final_project/internal/scheduler"
+	"log"
 
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -16,10 +22,72 @@ type TasksResp struct {
 	Tasks []*db.Task `json:"tasks"`
 }
 
-const limit = 50
+// IndexedTask - задача с порядковым номером текущего повторения в серии (include=occurrence_index).
+type IndexedTask struct {
+	*db.Task
+	OccurrenceIndex int `json:"occurrence_index"`
+}
+
+// IndexedTasksResp - ответ API со списком задач и номерами их повторений.
+type IndexedTasksResp struct {
+	Tasks []*IndexedTask `json:"tasks"`
+}
+
+// TaskSummariesResp - ответ API со списком задач в облегчённом представлении (view=summary).
+type TaskSummariesResp struct {
+	Tasks []*db.TaskSummary `json:"tasks"`
+}
+
+// limit - количество задач в ответе по умолчанию; maxPageLimit - максимальное значение параметра limit.
+const (
+	limit        = 50
+	maxPageLimit = 500
+)
+
+// Значения параметра include (через запятую), добавляющие данные в список задач.
+const (
+	includeArchived        = "archived"         // архивные задачи
+	includeOccurrenceIndex = "occurrence_index" // номер текущего повторения задачи в серии
+)
+
+// statusAll - значение параметра status, выводящее задачи в любом состоянии.
+const statusAll = "all"
+
+// Значения параметра view, задающего представление задач в списке.
+const (
+	viewFull    = "full"    // задачи целиком (по умолчанию)
+	viewSummary = "summary" // только id, date, title и repeat
+)
+
+// searchComments сообщает, ищется ли текст запроса в комментариях задач (переменная окружения TODO_SEARCH_COMMENTS).
+// По умолчанию (значение не задано или некорректно) поиск идёт и по заголовкам, и по комментариям;
+// при false - только по заголовкам.
+func searchComments() bool {
+	enabled, err := strconv.ParseBool(config.SearchComments)
+	if err != nil {
+		return true
+	}
+	return enabled
+}
 
 // tasksHandler - обработчик HTTP-запросов для получения списка задач.
-// Поддерживает фильтрацию по поисковому запросу (поиск по заголовку, комментарию или дате).
+// Поддерживает фильтрацию по поисковому запросу: по дате или по тексту в заголовке и комментарии
+// (с операторами AND/OR и фразами в кавычках, см. db.SearchTasks; поиск по комментариям отключается TODO_SEARCH_COMMENTS=false).
+// Архивные задачи выводятся только при include=archived.
+// При view=summary задачи возвращаются без комментария (см. db.TaskSummary).
+// Параметр repeat оставляет только задачи с указанным правилом повторения (точное совпадение).
+// Параметр status отбирает задачи по состоянию: pending (по умолчанию - активные задачи), done (выполненные
+// разовые задачи) или all (любые), см. taskStatusFilter.
+// При текстовом поиске с snippet=true комментарий заменяется фрагментом вокруг совпадения (см. makeSnippet).
+// При include=occurrence_index к каждой задаче добавляется номер текущего повторения в серии (см. withOccurrenceIndex);
+// значения include перечисляются через запятую (например, include=archived,occurrence_index).
+// При sort=next_occurrence задачи сортируются по ближайшему повторению, вычисленному от сегодняшнего дня
+// (см. sortByNextOccurrence), а не по сохранённой дате.
+// Постраничный вывод задаётся параметрами limit (по умолчанию 50, не больше maxPageLimit) и offset (по умолчанию 0),
+// см. pagination; offset за концом списка даёт пустой массив tasks.
+// Чтение никогда не изменяет задачи: даты возвращаются такими, как хранятся в БД (корректировка даты по правилу
+// повторения выполняется только при записи, см. checkDate). Параметр raw=true явно запрашивает хранимые даты
+// и несовместим с преобразованием формата (date_format=iso).
 // Параметры:
 // w - объект для записи HTTP-ответа;
 // r - объект HTTP-запроса.
@@ -27,8 +95,140 @@ func (s *APIServer) tasksHandler(w http.ResponseWriter, r *http.Request) {
 	// Получаем параметр search из строки запроса
 	searchQuery := r.URL.Query().Get("search")
 
-	// Вызываем БД для получения списка задач (максимум 50 записей)
-	tasks, err := db.GetTasks(s.DB, limit)
+	// Получаем формат дат для ответа (параметр date_format)
+	dateFormat, err := responseDateFormat(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// При raw=true даты возвращаются строго в формате хранения
+	if r.URL.Query().Get("raw") == "true" && dateFormat != dateFormatCompact {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "raw=true cannot be combined with date_format=iso",
+		})
+		return
+	}
+
+	// Получаем параметры постраничного вывода (limit и offset)
+	pageLimit, offset, err := pagination(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Получаем представление задач (параметр view)
+	view := r.URL.Query().Get("view")
+	if view != "" && view != viewFull && view != viewSummary {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "unsupported view: expected \"full\" or \"summary\"",
+		})
+		return
+	}
+
+	// По умолчанию архивные задачи не выводятся; include=archived добавляет их в список
+	include := map[string]bool{}
+	for _, value := range strings.Split(r.URL.Query().Get("include"), ",") {
+		include[strings.TrimSpace(value)] = true
+	}
+	includeArchived := include[includeArchived]
+	withIndex := include[includeOccurrenceIndex]
+	if withIndex && view == viewSummary {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "include=occurrence_index is not supported with view=summary",
+		})
+		return
+	}
+
+	// Фильтр по правилу повторения: пробелы нормализуются, пустое значение - задачи без повторения
+	filterRepeat := r.URL.Query().Has("repeat")
+	repeatRule := strings.Join(strings.Fields(r.URL.Query().Get("repeat")), " ")
+
+	// Фильтр по состоянию задачи (параметр status)
+	status, err := taskStatusFilter(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Получаем порядок сортировки (параметр sort)
+	sortOrder := r.URL.Query().Get("sort")
+	if sortOrder != "" && sortOrder != sortNextOccurrence {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "unsupported sort: expected \"next_occurrence\"",
+		})
+		return
+	}
+
+	// Облегчённый список без поиска, фильтров и сортировки выбирается из БД без лишних колонок
+	if view == viewSummary && searchQuery == "" && !includeArchived && !filterRepeat && status == db.StatusPending && sortOrder == "" {
+		s.taskSummaries(w, dateFormat, pageLimit, offset)
+		return
+	}
+
+	// Сортировка по ближайшему повторению выполняется после выборки, поэтому страница для неё вырезается в памяти
+	// из первых nextOccurrenceFetchLimit задач; остальные выборки (в том числе поиск) постранично выполняются в SQL
+	inMemory := sortOrder != ""
+	fetchLimit, fetchOffset := pageLimit, offset
+	if inMemory {
+		fetchLimit, fetchOffset = offset+pageLimit, 0
+	}
+	if sortOrder == sortNextOccurrence {
+		fetchLimit = nextOccurrenceFetchLimit
+	}
+
+	fetchTasks := func(database *sql.DB, limit, offset int) ([]*db.Task, error) {
+		return db.GetTasksByStatus(database, status, includeArchived, limit, offset)
+	}
+	if filterRepeat {
+		fetchTasks = func(database *sql.DB, limit, offset int) ([]*db.Task, error) {
+			return db.GetTasksByRepeat(database, repeatRule, includeArchived, status, limit, offset)
+		}
+	}
+
+	// Условия отбора результатов поиска (архив, состояние и правило повторения) применяются в SQL
+	filter := db.SearchFilter{
+		IncludeArchived: includeArchived,
+		Status:          status,
+		FilterRepeat:    filterRepeat,
+		Repeat:          repeatRule,
+	}
+
+	// Проверяем, является ли searchQuery датой в одном из допустимых форматов (см. searchDateFormats)
+	parsedDate, isDate := parseSearchDate(searchQuery)
+
+	var tasks []*db.Task
+	switch {
+	case isDate:
+		// Поиск по дате выполняется на стороне БД: запрос приводится к формату хранения (YYYYMMDD)
+		tasks, err = db.SearchTasksByDate(s.DB, parsedDate.Format(scheduler.DateFormat), filter, fetchLimit, fetchOffset)
+	case searchQuery != "":
+		// Текстовый поиск (с поддержкой AND/OR и фраз в кавычках) выполняется на стороне БД
+		tasks, err = db.SearchTasks(s.DB, searchQuery, searchComments(), filter, fetchLimit, fetchOffset)
+		if errors.Is(err, db.ErrInvalidSearch) {
+			api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+			return
+		}
+		// При snippet=true вместо полного комментария возвращаем фрагмент вокруг совпадения
+		if err == nil && r.URL.Query().Get("snippet") == "true" {
+			terms, _ := db.SearchTerms(searchQuery)
+			snippetLength := getSnippetLength()
+			for _, task := range tasks {
+				task.Comment = makeSnippet(task.Comment, terms, snippetLength)
+			}
+		}
+	default:
+		// Вызываем БД для получения списка задач (страница limit/offset, для сортировки - fetchLimit)
+		tasks, err = fetchTasks(s.DB, fetchLimit, fetchOffset)
+	}
 	if err != nil {
 		// Возвращаем HTTP 500 с сообщением об ошибке
 		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
@@ -42,46 +242,43 @@ func (s *APIServer) tasksHandler(w http.ResponseWriter, r *http.Request) {
 		tasks = []*db.Task{}
 	}
 
-	// Если есть поисковый запрос - фильтруем задачи
-	if searchQuery != "" {
-		filteredTasks := []*db.Task{}
+	// Сортируем по ближайшему повторению
+	if sortOrder == sortNextOccurrence {
+		sortByNextOccurrence(tasks, time.Now())
+	}
 
-		// Проверяем, является ли searchQuery датой в формате scheduler.DateFormat
-		isDate := false
-		parsedDate, err := time.Parse(scheduler.DateFormat, searchQuery)
-		if err == nil {
-			isDate = true
-		}
+	// Вырезаем запрошенную страницу из отобранных в памяти задач
+	if inMemory {
+		tasks = page(tasks, pageLimit, offset)
+	}
 
-		// Если не получилось, пробуем альтернативный формат DD.MM.YYYY
-		if !isDate {
-			parsedDate, err = time.Parse("02.01.2006", searchQuery)
-			isDate = err == nil
+	// Номера повторений вычисляются по хранимым датам, до перевода в формат ответа
+	if withIndex {
+		indexed, err := s.withOccurrenceIndex(tasks)
+		if err != nil {
+			writeInternalError(w, "failed to compute occurrence indexes", err)
+			return
 		}
+		formatTaskDates(dateFormat, tasks...)
+		api.WriteJSON(w, http.StatusOK, IndexedTasksResp{
+			Tasks: indexed,
+		})
+		return
+	}
+
+	// Приводим даты к запрошенному формату
+	formatTaskDates(dateFormat, tasks...)
 
-		// Проходим по всем задачам и отбираем подходящие под фильтр
+	// Для облегчённого представления отбрасываем лишние поля
+	if view == viewSummary {
+		summaries := make([]*db.TaskSummary, 0, len(tasks))
 		for _, task := range tasks {
-			if isDate {
-				// Преобразуем строку из задачи в time.Time
-				taskDate, err := time.Parse(scheduler.DateFormat, task.Date)
-				if err != nil {
-					taskDate, err = time.Parse("02.01.2006", task.Date)
-					if err != nil {
-						continue
-					}
-				}
-				// Сравниваем даты на равенство
-				if taskDate.Equal(parsedDate) {
-					filteredTasks = append(filteredTasks, task)
-				}
-			} else {
-				// Проверяем, содержится ли поисковая строка в заголовке или комментарии (без учёта регистра)
-				if strings.Contains(strings.ToLower(task.Title), strings.ToLower(searchQuery)) || strings.Contains(strings.ToLower(task.Comment), strings.ToLower(searchQuery)) {
-					filteredTasks = append(filteredTasks, task)
-				}
-			}
+			summaries = append(summaries, task.Summary())
 		}
-		tasks = filteredTasks
+		api.WriteJSON(w, http.StatusOK, TaskSummariesResp{
+			Tasks: summaries,
+		})
+		return
 	}
 
 	// Формируем и отправляем ответ в формате JSON с кодом 200 (OK)
@@ -89,3 +286,116 @@ func (s *APIServer) tasksHandler(w http.ResponseWriter, r *http.Request) {
 		Tasks: tasks,
 	})
 }
+
+// pagination возвращает параметры постраничного вывода из строки запроса.
+// Параметры:
+// r - объект HTTP-запроса.
+// Возвращает:
+// limit (по умолчанию 50, от 1 до maxPageLimit), offset (по умолчанию 0)
+// и ошибку для отрицательных, нечисловых или слишком больших значений.
+func pagination(r *http.Request) (int, int, error) {
+	pageLimit, offset := limit, 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxPageLimit {
+			return 0, 0, fmt.Errorf("limit must be an integer in range [1, %d]", maxPageLimit)
+		}
+		pageLimit = parsed
+	}
+	if value := r.URL.Query().Get("offset"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			return 0, 0, errors.New("offset must be a non-negative integer")
+		}
+		offset = parsed
+	}
+	return pageLimit, offset, nil
+}
+
+// taskStatusFilter возвращает состояние задач для отбора из параметра status.
+// Параметры:
+// r - объект HTTP-запроса.
+// Возвращает:
+// db.StatusPending (по умолчанию), db.StatusDone, пустую строку для status=all (любое состояние)
+// и ошибку для неизвестного значения.
+func taskStatusFilter(r *http.Request) (string, error) {
+	switch status := r.URL.Query().Get("status"); status {
+	case "":
+		return db.StatusPending, nil
+	case statusAll:
+		return "", nil
+	case db.StatusPending, db.StatusDone:
+		return status, nil
+	default:
+		return "", errors.New("unsupported status: expected \"pending\", \"done\" or \"all\"")
+	}
+}
+
+// page возвращает не больше pageLimit задач, начиная с позиции offset (пустой слайс, если offset за концом списка).
+func page(tasks []*db.Task, pageLimit, offset int) []*db.Task {
+	if offset >= len(tasks) {
+		return []*db.Task{}
+	}
+	tasks = tasks[offset:]
+	if len(tasks) > pageLimit {
+		tasks = tasks[:pageLimit]
+	}
+	return tasks
+}
+
+// taskSummaries отправляет облегчённый список активных задач (view=summary).
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// dateFormat - формат дат из responseDateFormat;
+// pageLimit, offset - параметры постраничного вывода (см. pagination).
+func (s *APIServer) taskSummaries(w http.ResponseWriter, dateFormat string, pageLimit, offset int) {
+	summaries, err := db.GetTaskSummaries(s.DB, pageLimit, offset)
+	if err != nil {
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to fetch tasks from database",
+		})
+		return
+	}
+
+	// Приводим даты к запрошенному формату
+	if dateFormat == dateFormatISO {
+		for _, summary := range summaries {
+			if t, err := time.Parse(scheduler.DateFormat, summary.Date); err == nil {
+				summary.Date = t.Format(scheduler.ISODateFormat)
+			}
+		}
+	}
+
+	api.WriteJSON(w, http.StatusOK, TaskSummariesResp{
+		Tasks: summaries,
+	})
+}
+
+// withOccurrenceIndex добавляет к задачам номер текущего повторения в серии (см. scheduler.OccurrenceIndex).
+// Началом серии считается самая ранняя запланированная дата из отметок о выполнении задачи;
+// у задачи без отметок текущая дата - первое повторение.
+// Параметры:
+// tasks - задачи с датами в формате scheduler.DateFormat.
+// Возвращает: задачи с номерами повторений и ошибку чтения из БД.
+func (s *APIServer) withOccurrenceIndex(tasks []*db.Task) ([]*IndexedTask, error) {
+	starts, err := db.GetFirstScheduledDates(s.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	indexed := make([]*IndexedTask, 0, len(tasks))
+	for _, task := range tasks {
+		start, ok := starts[task.ID]
+		if !ok {
+			start = task.Date
+		}
+		index, err := scheduler.OccurrenceIndex(start, task.Repeat, task.Date)
+		if err != nil {
+			// Некорректное правило не мешает выводу списка: считаем задачу первым повторением
+			log.Printf("failed to compute occurrence index for task %s: %v", task.ID, err)
+			index = 1
+		}
+		indexed = append(indexed, &IndexedTask{Task: task, OccurrenceIndex: index})
+	}
+	return indexed, nil
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"log"
+	"net/http"
+	"time"
+)
+
+// groupedTasksHandler - обработчик HTTP-запроса для получения задач, сгруппированных по срокам:
+// просроченные (overdue), на сегодня (today) и предстоящие (upcoming).
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса.
+func (s *APIServer) groupedTasksHandler(w http.ResponseWriter, r *http.Request) {
+	// Получаем формат дат для ответа (параметр date_format)
+	dateFormat, err := responseDateFormat(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Текущая дата, относительно которой распределяются задачи
+	today := time.Now().Format(scheduler.DateFormat)
+
+	// Получаем группы задач (не более limit задач в каждой)
+	groups, err := db.GetGroupedTasks(s.DB, today, limit)
+	if err != nil {
+		log.Printf("failed to fetch grouped tasks: %v", err)
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to fetch tasks from database",
+		})
+		return
+	}
+
+	// Приводим даты к запрошенному формату
+	formatTaskDates(dateFormat, groups.Overdue...)
+	formatTaskDates(dateFormat, groups.Today...)
+	formatTaskDates(dateFormat, groups.Upcoming...)
+
+	// Формируем и отправляем ответ в формате JSON с кодом 200 (OK)
+	api.WriteJSON(w, http.StatusOK, groups)
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"net/http"
+	"strconv"
+)
+
+// importTasksLimit - максимальное количество задач в одном запросе на импорт.
+const importTasksLimit = 10000
+
+// importHandler импортирует массив задач (в формате GET /api/export) в одной транзакции.
+// Каждая задача проверяется так же, как в importValidateHandler; при любой ошибке ничего не сохраняется.
+// Даты задач сохраняются как есть (без переноса прошедших дат и выходных), чтобы выгрузка переносилась без изменений.
+// По умолчанию ID из импорта игнорируются и назначаются заново; при keep_ids=true задачи сохраняются
+// с исходными ID (для переноса данных между экземплярами), а совпадение с существующим ID даёт 409.
+// Параметры:
+// w - http.ResponseWriter для отправки ответа клиенту;
+// r - *http.Request с JSON-массивом задач.
+func (s *APIServer) importHandler(w http.ResponseWriter, r *http.Request) {
+	keepIDs := false
+	if value := r.URL.Query().Get("keep_ids"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+				"error": "keep_ids must be a boolean",
+			})
+			return
+		}
+		keepIDs = parsed
+	}
+
+	// Читаем тело запроса, отклоняя некорректный UTF-8 до сохранения в БД
+	body, err := readUTF8Body(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Декодируем массив задач
+	var tasks []*db.Task
+	if err := json.Unmarshal(body, &tasks); err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid JSON payload: expected an array of tasks",
+		})
+		return
+	}
+	if len(tasks) > importTasksLimit {
+		api.WriteValidationError(w, fmt.Sprintf("too many tasks: at most %d allowed", importTasksLimit))
+		return
+	}
+
+	for i, task := range tasks {
+		if task == nil {
+			api.WriteValidationError(w, fmt.Sprintf("tasks[%d]: task cannot be null", i))
+			return
+		}
+		if err := validateTask(task); err != nil {
+			api.WriteValidationError(w, fmt.Sprintf("tasks[%d]: %v", i, err))
+			return
+		}
+		// Состояние задачи из выгрузки должно быть известным (пустое - активная задача)
+		if task.Status != "" && !db.ValidStatus(task.Status) {
+			api.WriteValidationError(w, fmt.Sprintf("tasks[%d]: invalid status %q: expected \"pending\" or \"done\"", i, task.Status))
+			return
+		}
+		// Сохраняемый ID должен быть положительным целым числом
+		if id, err := strconv.ParseInt(task.ID, 10, 64); keepIDs && (err != nil || id <= 0) {
+			api.WriteValidationError(w, fmt.Sprintf("tasks[%d]: invalid id %q: must be a positive integer", i, task.ID))
+			return
+		}
+	}
+
+	ids, err := db.ImportTasks(s.DB, tasks, keepIDs)
+	if err != nil {
+		// Задача с таким ID уже есть - сообщаем, какой именно ID занят
+		if errors.Is(err, db.ErrDuplicateID) {
+			api.WriteJSON(w, http.StatusConflict, map[string]string{
+				"error": err.Error(),
+			})
+			return
+		}
+		// Нарушение ограничения целостности (например, уникального индекса) - возвращаем 409 (Conflict)
+		if errors.Is(err, db.ErrConflict) {
+			api.WriteJSON(w, http.StatusConflict, map[string]string{
+				"error": "task conflicts with an existing task",
+			})
+			return
+		}
+		writeInternalError(w, "failed to import tasks", err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusCreated, map[string][]int64{
+		"ids": ids,
+	})
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// importValidationResult - результат проверки одной строки импорта.
+type importValidationResult struct {
+	Index int    `json:"index"`
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+// validateTask проверяет задачу из импорта: текст, заголовок, цвет, длительность, формат даты и правило повторения.
+// В отличие от checkDate, дата не корректируется: задачи из выгрузки сохраняются с исходными датами
+// (в том числе прошедшими и выпадающими на выходные); только пустая дата и "today" заменяются текущей.
+// Параметры:
+// task - указатель на проверяемую задачу.
+// Возвращает: ошибку с описанием первой найденной проблемы или nil.
+func validateTask(task *db.Task) error {
+	// Заголовок и комментарий должны быть корректным UTF-8
+	if err := checkTaskText(task); err != nil {
+		return err
+	}
+
+	// Заголовок - обязательное поле
+	if strings.TrimSpace(task.Title) == "" {
+		return errors.New("title cannot be empty")
+	}
+
+	// Проверяем цвет метки
+	if err := checkColor(task); err != nil {
+		return err
+	}
+
+	// Проверяем оценку длительности
+	if err := checkDuration(task); err != nil {
+		return err
+	}
+
+	// Проверяем формат даты, не изменяя её
+	now := time.Now()
+	if task.Date == "" || task.Date == "today" {
+		task.Date = now.Format(scheduler.DateFormat)
+	}
+	if _, err := time.Parse(scheduler.DateFormat, task.Date); err != nil {
+		return err
+	}
+
+	// Правило повторения проверяем для любой даты
+	if task.Repeat != "" {
+		if _, err := scheduler.NextDate(now, task.Date, task.Repeat); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importValidateHandler проверяет массив задач перед импортом, ничего не записывая в базу данных.
+// Для каждой строки возвращает её индекс, признак корректности и описание ошибки.
+// Параметры:
+// w - http.ResponseWriter для отправки ответа клиенту;
+// r - *http.Request, входящий HTTP-запрос с JSON-массивом задач.
+func importValidateHandler(w http.ResponseWriter, r *http.Request) {
+	// Читаем тело запроса, отклоняя некорректный UTF-8
+	body, err := readUTF8Body(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	var tasks []db.Task
+	if err := json.Unmarshal(body, &tasks); err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid JSON payload: expected an array of tasks",
+		})
+		return
+	}
+
+	// Проверяем каждую строку независимо от остальных
+	results := make([]importValidationResult, 0, len(tasks))
+	for i := range tasks {
+		result := importValidationResult{Index: i, Valid: true}
+		if err := validateTask(&tasks[i]); err != nil {
+			result.Valid = false
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+
+	api.WriteJSON(w, http.StatusOK, map[string]any{
+		"results": results,
+	})
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"go-task-manager-final_project/config"
+	"go-task-manager-final_project/internal/api"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// debugErrors сообщает, включены ли подробные сообщения о внутренних ошибках (переменная окружения TODO_DEBUG_ERRORS).
+// По умолчанию (значение не задано или некорректно) подробности клиенту не отправляются.
+func debugErrors() bool {
+	enabled, _ := strconv.ParseBool(config.DebugErrors)
+	return enabled
+}
+
+// writeInternalError отправляет ответ 500 (Internal Server Error) для внутренней ошибки.
+// Подробности ошибки всегда пишутся в лог сервера, а клиенту передаются только при TODO_DEBUG_ERRORS=true:
+// в остальных случаях клиент получает общее сообщение (без текста ошибок БД и т. п.).
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// message - общее сообщение об ошибке для клиента;
+// err - исходная ошибка.
+func writeInternalError(w http.ResponseWriter, message string, err error) {
+	log.Printf("%s: %v", message, err)
+	if debugErrors() {
+		message += ": " + err.Error()
+	}
+	api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+		"error": message,
+	})
+}
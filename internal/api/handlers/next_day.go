@@ -10,7 +10,7 @@ import (
 
 // nextDayHandler обрабатывает HTTP‑запрос на вычисление следующей даты по правилу повторения.
 // Ожидает GET‑запрос с параметрами:
-// - now (текущая дата в формате scheduler.DateFormat);
+// - now (текущая дата в формате scheduler.DateFormat; если не задана - текущая дата сервера);
 // - date (стартовая дата в текстовом формате);
 // - repeat (правило повторения, определяющее периодичность).
 // Возвращает:
@@ -23,15 +23,20 @@ func handleNextDay(w http.ResponseWriter, r *http.Request) {
 	date := r.FormValue("date")
 	repeat := r.FormValue("repeat")
 
-	// Парсим строку с текущей датой в тип time.Time
-	// Используем формат, определённый в пакете scheduler (scheduler.DateFormat)
-	now, err := time.Parse(scheduler.DateFormat, nowString)
-	if err != nil {
-		// Если формат даты некорректен, возвращаем ошибку 400 Bad Request
-		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
-			"error": "invalid 'now' date format",
-		})
-		return
+	// Если параметр now не передан, считаем от текущей даты сервера
+	now := time.Now()
+	if nowString != "" {
+		// Парсим строку с текущей датой в тип time.Time
+		// Используем формат, определённый в пакете scheduler (scheduler.DateFormat)
+		parsed, err := time.Parse(scheduler.DateFormat, nowString)
+		if err != nil {
+			// Если формат даты некорректен, возвращаем ошибку 400 Bad Request
+			api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+				"error": "invalid 'now' date format",
+			})
+			return
+		}
+		now = parsed
 	}
 
 	// Вычисляем следующую дату с помощью функции из пакета scheduler
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"log"
+	"sort"
+	"time"
+)
+
+// sortNextOccurrence - значение параметра sort для сортировки задач по ближайшему повторению.
+const sortNextOccurrence = "next_occurrence"
+
+// nextOccurrenceFetchLimit - максимальное количество задач, выбираемых из БД для сортировки по ближайшему повторению.
+// Сортировка выполняется после выборки, поэтому задач выбирается больше, чем возвращается в ответе.
+const nextOccurrenceFetchLimit = 1000
+
+// nextOccurrence возвращает ближайшую дату задачи не раньше сегодняшнего дня.
+// Для повторяющейся задачи с датой в прошлом дата вычисляется по правилу повторения,
+// в остальных случаях используется дата задачи.
+// Параметры:
+// task - задача;
+// today - начало сегодняшнего дня.
+// Возвращает: дату в формате scheduler.DateFormat.
+func nextOccurrence(task *db.Task, today time.Time) string {
+	if task.Repeat == "" || task.Date >= today.Format(scheduler.DateFormat) {
+		return task.Date
+	}
+	// Первое повторение строго после вчерашнего дня - то есть не раньше сегодняшнего
+	next, err := scheduler.NextDate(today.AddDate(0, 0, -1), task.Date, task.Repeat)
+	if err != nil {
+		log.Printf("failed to compute next occurrence of task %s: %v", task.ID, err)
+		return task.Date
+	}
+	return next
+}
+
+// sortByNextOccurrence сортирует задачи по ближайшему повторению (см. nextOccurrence);
+// при совпадении дат сохраняется исходный порядок.
+// Параметры:
+// tasks - задачи для сортировки (сортируются на месте);
+// now - текущее время.
+func sortByNextOccurrence(tasks []*db.Task, now time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	keys := make(map[*db.Task]string, len(tasks))
+	for _, task := range tasks {
+		keys[task] = nextOccurrence(task, today)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return keys[tasks[i]] < keys[tasks[j]]
+	})
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"fmt"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/scheduler"
+	"net/http"
+	"strings"
+)
+
+// normalizeDateHandler обрабатывает HTTP‑запрос на проверку и нормализацию даты.
+// Ожидает GET‑запрос с параметром value - дата в одном из поддерживаемых форматов (см. searchDateFormats).
+// Возвращает JSON вида {"date": "20240115"} (формат scheduler.DateFormat)
+// или ошибку 400, если дата не задана или не распознана.
+func normalizeDateHandler(w http.ResponseWriter, r *http.Request) {
+	value := strings.TrimSpace(r.URL.Query().Get("value"))
+	if value == "" {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "value parameter required",
+		})
+		return
+	}
+
+	date, ok := parseSearchDate(value)
+	if !ok {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("invalid date %q: expected one of formats %s", value, strings.Join(searchDateFormats(), ", ")),
+		})
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, map[string]string{
+		"date": date.Format(scheduler.DateFormat),
+	})
+}
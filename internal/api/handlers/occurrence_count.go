@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// occurrenceCountLimit - максимальное количество повторений, которое обходится при подсчёте
+// (защита от слишком длинных диапазонов: ежедневная задача за 27 лет).
+const occurrenceCountLimit = 10000
+
+// occurrenceCountHandler обрабатывает запрос на подсчёт повторений задачи в диапазоне [from, to] включительно.
+// Повторения перебираются по правилу задачи до даты to; если их больше occurrenceCountLimit,
+// возвращается occurrenceCountLimit с признаком truncated.
+// Параметры:
+// w - http.ResponseWriter для отправки ответа клиенту;
+// r - *http.Request с параметрами id, from и to.
+func (s *APIServer) occurrenceCountHandler(w http.ResponseWriter, r *http.Request) {
+	// Получаем параметр id из строки запроса
+	id := r.URL.Query().Get("id")
+
+	// Проверяем, что ID не пустой и не состоит только из пробелов
+	if strings.TrimSpace(id) == "" {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "id parameter required",
+		})
+		return
+	}
+
+	// Проверяем формат ID (числовой)
+	if _, err := strconv.Atoi(id); err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid id format: must be a integer number",
+		})
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Получаем задачу из базы данных по указанному ID
+	task, err := db.GetTask(s.DB, id)
+	if err != nil {
+		if errors.Is(err, db.ErrTaskNotFound) {
+			api.WriteJSON(w, http.StatusNotFound, map[string]string{
+				"error": "task not found",
+			})
+		} else {
+			api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+				"error": "could not retrieve task from database",
+			})
+		}
+		return
+	}
+
+	// Запрашиваем на одно повторение больше лимита, чтобы отличить ровно occurrenceCountLimit от обрезанного списка
+	dates, err := scheduler.Occurrences(task.Date, task.Repeat, from, to, occurrenceCountLimit+1)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid repeat pattern: " + err.Error(),
+		})
+		return
+	}
+
+	count, truncated := len(dates), false
+	if count > occurrenceCountLimit {
+		count, truncated = occurrenceCountLimit, true
+	}
+
+	api.WriteJSON(w, http.StatusOK, map[string]any{
+		"count":     count,
+		"truncated": truncated,
+	})
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// taskPatch - тело запроса на частичное обновление задачи.
+// Поля-указатели различают отсутствие поля (nil - значение не меняется) и явное пустое значение:
+// например, "repeat": "" делает задачу разовой, а отсутствие repeat оставляет правило как есть.
+type taskPatch struct {
+	Date    *string `json:"date"`
+	Title   *string `json:"title"`
+	Comment *string `json:"comment"`
+	Repeat  *string `json:"repeat"`
+	Color   *string `json:"color"`
+
+	DurationMinutes *int `json:"duration_minutes"`
+}
+
+// apply переносит переданные поля в задачу.
+// Возвращает true, если изменились дата или правило повторения и дату нужно проверить заново.
+func (p *taskPatch) apply(task *db.Task) bool {
+	if p.Title != nil {
+		task.Title = *p.Title
+	}
+	if p.Comment != nil {
+		task.Comment = *p.Comment
+	}
+	if p.Color != nil {
+		task.Color = *p.Color
+	}
+	if p.DurationMinutes != nil {
+		task.DurationMinutes = *p.DurationMinutes
+	}
+	if p.Date != nil {
+		task.Date = *p.Date
+	}
+	if p.Repeat != nil {
+		task.Repeat = *p.Repeat
+	}
+	return p.Date != nil || p.Repeat != nil
+}
+
+// patchTaskHandler обрабатывает HTTP-запрос на частичное обновление задачи.
+// Изменяются только поля, переданные в теле запроса; остальные сохраняют текущие значения.
+// Дата проверяется и корректируется (как в putTaskHandler), только если переданы date или repeat.
+// Параметры:
+// w - объект http.ResponseWriter для отправки ответа клиенту;
+// r - объект *http.Request с параметром id и JSON-телом, например {"repeat": ""}.
+func (s *APIServer) patchTaskHandler(w http.ResponseWriter, r *http.Request) {
+	// Проверяем, что Content-Type начинается с "application/json" (без учёта регистра)
+	if !strings.HasPrefix(strings.ToLower(r.Header.Get("Content-Type")), "application/json") {
+		api.WriteJSON(w, http.StatusUnsupportedMediaType, map[string]string{
+			"error": "content-Type must be application/json",
+		})
+		return
+	}
+
+	// Получаем параметр id из строки запроса
+	id := r.URL.Query().Get("id")
+
+	// Проверяем, что ID не пустой и не состоит только из пробелов
+	if strings.TrimSpace(id) == "" {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "id parameter required",
+		})
+		return
+	}
+
+	// Проверяем формат ID (числовой)
+	if _, err := strconv.Atoi(id); err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid id format: must be a integer number",
+		})
+		return
+	}
+
+	// Читаем тело запроса, отклоняя некорректный UTF-8 до сохранения в БД
+	body, err := readUTF8Body(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	var patch taskPatch
+	if err := json.Unmarshal(body, &patch); err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid JSON payload",
+		})
+		return
+	}
+
+	// Получаем текущую задачу из базы данных
+	task, err := db.GetTask(s.DB, id)
+	if err != nil {
+		if errors.Is(err, db.ErrTaskNotFound) {
+			api.WriteJSON(w, http.StatusNotFound, map[string]string{
+				"error": "task not found",
+			})
+		} else {
+			api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+				"error": "could not retrieve task from database",
+			})
+		}
+		return
+	}
+
+	dateChanged := patch.apply(task)
+
+	// Проверяем, что текст задачи - корректный UTF-8
+	if err := checkTaskText(task); err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Заголовок остаётся обязательным
+	if strings.TrimSpace(task.Title) == "" {
+		api.WriteValidationError(w, "title cannot be empty or whitespace")
+		return
+	}
+
+	// Проверяем цвет метки задачи
+	if err := checkColor(task); err != nil {
+		api.WriteValidationError(w, err.Error())
+		return
+	}
+
+	// Проверяем оценку длительности задачи
+	if err := checkDuration(task); err != nil {
+		api.WriteValidationError(w, err.Error())
+		return
+	}
+
+	// Дата проверяется, только если изменились дата или правило повторения
+	if dateChanged {
+		if err := checkDate(task); err != nil {
+			api.WriteValidationError(w, err.Error())
+			return
+		}
+	}
+
+	// Изменённое правило повторения проверяем и для будущих дат, где checkDate его не вычисляет
+	if patch.Repeat != nil && task.Repeat != "" {
+		if _, err := scheduler.NextDate(time.Now(), task.Date, task.Repeat); err != nil {
+			api.WriteValidationError(w, err.Error())
+			return
+		}
+	}
+
+	err = db.UpdateTask(s.DB, task)
+	if err != nil {
+		// Нарушение ограничения целостности (например, уникального индекса) - возвращаем 409 (Conflict)
+		if errors.Is(err, db.ErrConflict) {
+			api.WriteJSON(w, http.StatusConflict, map[string]string{
+				"error": "task conflicts with an existing task",
+			})
+			return
+		}
+		writeInternalError(w, "failed to update task", err)
+		return
+	}
+
+	// Отправляем успешный ответ с ID задачи, ссылкой на ресурс и задачей после обновления
+	api.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"id":       task.ID,
+		"location": fmt.Sprintf("/tasks/%s", task.ID),
+		"message":  "Task update successfully",
+		"task":     task,
+	})
+}
@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"go-task-manager-final_project/internal/api"
+	"net/http"
+)
+
+// pingHandler - проверка живости процесса (liveness probe).
+// Не обращается к БД, поэтому недоступность БД не приводит к перезапуску процесса.
+// Возвращает JSON вида {"pong": true} с кодом 200.
+func pingHandler(w http.ResponseWriter, r *http.Request) {
+	api.WriteJSON(w, http.StatusOK, map[string]bool{
+		"pong": true,
+	})
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go-task-manager-final_project/internal/api"
 	"go-task-manager-final_project/internal/db"
@@ -16,6 +17,7 @@ import (
 // Логика:
 // - проверяет заголовок Content-Type на соответствие application/json;
 // - декодирует JSON из тела запроса в структуру db.Task;
+// - берёт id из пути (/api/task/{id}), если он передан, иначе из тела запроса;
 // - валидирует обязательные поля (например, Title);
 // - проверяет и корректирует дату задачи;
 // - обновляет задачу в базе данных;
@@ -31,38 +33,86 @@ func (s *APIServer) putTaskHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Читаем тело запроса, отклоняя некорректный UTF-8 до сохранения в БД
+	body, err := readUTF8Body(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
 	// Создаём переменную для хранения данных задачи
 	var task db.Task
 	// Декодируем JSON из тела запроса в структуру task
-	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
+	if err := json.Unmarshal(body, &task); err != nil {
 		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
 			"error": fmt.Sprintf("invalid JSON payload: %v", err),
 		})
 		return
 	}
 
-	// Проверяем, что поле Title не пустое (обязательное поле)
-	if strings.TrimSpace(task.Title) == "" {
+	// id из пути (или строки запроса) имеет приоритет, но не должен противоречить id в теле
+	if id := taskIDParam(r); id != "" {
+		if task.ID != "" && task.ID != id {
+			api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+				"error": "id in request body does not match id in path",
+			})
+			return
+		}
+		task.ID = id
+	}
+
+	// Проверяем, что текст задачи - корректный UTF-8
+	if err := checkTaskText(&task); err != nil {
 		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
-			"error": "title cannot be empty or whitespace",
+			"error": err.Error(),
 		})
 		return
 	}
 
+	// Проверяем, что поле Title не пустое (обязательное поле)
+	if strings.TrimSpace(task.Title) == "" {
+		api.WriteValidationError(w, "title cannot be empty or whitespace")
+		return
+	}
+
+	// Проверяем цвет метки задачи
+	if err := checkColor(&task); err != nil {
+		api.WriteValidationError(w, err.Error())
+		return
+	}
+
+	// Проверяем оценку длительности задачи
+	if err := checkDuration(&task); err != nil {
+		api.WriteValidationError(w, err.Error())
+		return
+	}
+
 	// Проверяем и корректируем дату задачи (вызов вспомогательной функции)
 	if err := checkDate(&task); err != nil {
-		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
-			"error": err.Error(),
-		})
+		api.WriteValidationError(w, err.Error())
 		return
 	}
 
 	// Обновляем задачу в базе данных через функцию UpdateTask из пакета db
-	err := db.UpdateTask(s.DB, &task)
+	err = db.UpdateTask(s.DB, &task)
 	if err != nil {
-		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
-			"error": fmt.Sprintf("failed to update task: %v", err),
-		})
+		// Нарушение ограничения целостности (например, уникального индекса) - возвращаем 409 (Conflict)
+		if errors.Is(err, db.ErrConflict) {
+			api.WriteJSON(w, http.StatusConflict, map[string]string{
+				"error": "task conflicts with an existing task",
+			})
+			return
+		}
+		// Задачи с таким ID нет - возвращаем 404 (Not Found)
+		if errors.Is(err, db.ErrTaskNotFound) {
+			api.WriteJSON(w, http.StatusNotFound, map[string]string{
+				"error": "task not found",
+			})
+			return
+		}
+		writeInternalError(w, "failed to update task", err)
 		return
 	}
 
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/api/middleware"
+	"log"
+	"net/http"
+	"time"
+)
+
+// readOnlyRequest - структура для приёма данных из запроса на переключение режима "только чтение".
+// MaintenanceUntil (RFC 3339) и Message - необязательные сведения об окне обслуживания для ответов 503.
+type readOnlyRequest struct {
+	Enabled          *bool  `json:"enabled"`
+	MaintenanceUntil string `json:"maintenance_until"`
+	Message          string `json:"message"`
+}
+
+// readOnlyResp - состояние режима "только чтение" и сведения об окне обслуживания (если заданы).
+type readOnlyResp struct {
+	ReadOnly         bool   `json:"read_only"`
+	MaintenanceUntil string `json:"maintenance_until,omitempty"`
+	Message          string `json:"message,omitempty"`
+}
+
+// readOnlyHandler обрабатывает запрос на получение (GET) или переключение (POST) режима "только чтение".
+// POST ожидает JSON вида {"enabled": true, "maintenance_until": "2024-01-26T18:00:00Z", "message": "..."},
+// где maintenance_until и message необязательны и сообщаются клиентам в ответах 503.
+// Параметры:
+// w - http.ResponseWriter для отправки ответа клиенту;
+// r - *http.Request, входящий HTTP-запрос.
+func readOnlyHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		var req readOnlyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Enabled == nil {
+			api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+				"error": `invalid JSON payload: expected {"enabled": true|false}`,
+			})
+			return
+		}
+
+		info := middleware.Maintenance{Message: req.Message}
+		if req.MaintenanceUntil != "" {
+			until, err := time.Parse(time.RFC3339, req.MaintenanceUntil)
+			if err != nil {
+				api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+					"error": "invalid maintenance_until: expected RFC 3339 timestamp",
+				})
+				return
+			}
+			info.Until = until
+		}
+
+		middleware.SetMaintenance(*req.Enabled, info)
+		log.Printf("Режим только для чтения: %t", *req.Enabled)
+	}
+
+	resp := readOnlyResp{ReadOnly: middleware.IsReadOnly()}
+	info := middleware.CurrentMaintenance()
+	if !info.Until.IsZero() {
+		resp.MaintenanceUntil = info.Until.Format(time.RFC3339)
+	}
+	resp.Message = info.Message
+	api.WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"go-task-manager-final_project/config"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/api/middleware"
+	"net/http"
+)
+
+// refreshHandler выдаёт новый JWT-токен с продлённым сроком действия без повторного ввода пароля.
+// Вызывается через middleware.Auth, поэтому истёкший или недействительный токен получает 401 и не обновляется.
+// Новый токен возвращается в поле "token" и устанавливается в cookie "token".
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса.
+func refreshHandler(w http.ResponseWriter, r *http.Request) {
+	// Без пароля аутентификация отключена и обновлять нечего
+	claims, ok := middleware.Claims(r)
+	if !config.AuthEnabled() || !ok {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "authentication is disabled: no token to refresh",
+		})
+		return
+	}
+
+	// Хэш пароля в токене уже проверен middleware.Auth - переносим его в новый токен
+	passwordHash, _ := claims["password_hash"].(string)
+	signedToken, expiresAt, err := signToken(passwordHash, []byte(config.JWTSecret))
+	if err != nil {
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to generate JWT token",
+		})
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:    "token",
+		Value:   signedToken,
+		Path:    "/",
+		Expires: expiresAt,
+	})
+	api.WriteJSON(w, http.StatusOK, map[string]string{
+		"token": signedToken,
+	})
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+)
+
+// rescheduleOverdueHandler переносит все просроченные задачи на сегодняшнюю дату.
+// "Сегодня" определяется в локальном часовом поясе сервера (переменная окружения TZ).
+// Параметр once_only=true ограничивает перенос задачами без правила повторения.
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса.
+func (s *APIServer) rescheduleOverdueHandler(w http.ResponseWriter, r *http.Request) {
+	onceOnly := false
+	if value := r.URL.Query().Get("once_only"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+				"error": "once_only must be a boolean",
+			})
+			return
+		}
+		onceOnly = parsed
+	}
+
+	today := time.Now().Format(scheduler.DateFormat)
+
+	updated, err := db.RescheduleOverdue(s.DB, today, onceOnly)
+	if err != nil {
+		// Перенос нарушает ограничение (например, уникальность заголовка в пределах даты)
+		if errors.Is(err, db.ErrConflict) {
+			api.WriteJSON(w, http.StatusConflict, map[string]string{
+				"error": "task conflicts with an existing task",
+			})
+			return
+		}
+		log.Printf("failed to reschedule overdue tasks: %v", err)
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "could not reschedule overdue tasks",
+		})
+		return
+	}
+
+	// Возвращаем количество перенесённых задач
+	api.WriteJSON(w, http.StatusOK, map[string]int64{
+		"updated": updated,
+	})
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"go-task-manager-final_project/config"
+	"go-task-manager-final_project/internal/scheduler"
+	"strings"
+	"time"
+)
+
+// defaultSearchDateFormats - форматы, в которых поисковый запрос распознаётся как дата (по умолчанию):
+// формат хранения (YYYYMMDD), DD.MM.YYYY и ISO (YYYY-MM-DD).
+var defaultSearchDateFormats = []string{scheduler.DateFormat, "02.01.2006", scheduler.ISODateFormat}
+
+// searchDateFormats возвращает форматы дат для поиска из переменной окружения TODO_SEARCH_DATE_FORMATS
+// (раскладки Go через запятую, например "20060102,2006-01-02").
+// Если значение не задано или не содержит ни одного формата, используется defaultSearchDateFormats.
+func searchDateFormats() []string {
+	var formats []string
+	for _, format := range strings.Split(config.SearchDateFormats, ",") {
+		if format = strings.TrimSpace(format); format != "" {
+			formats = append(formats, format)
+		}
+	}
+	if len(formats) == 0 {
+		return defaultSearchDateFormats
+	}
+	return formats
+}
+
+// parseSearchDate пытается разобрать поисковый запрос как дату в одном из форматов searchDateFormats.
+// Параметры:
+// query - поисковый запрос.
+// Возвращает: дату и true, если запрос распознан как дата, иначе нулевое время и false.
+func parseSearchDate(query string) (time.Time, bool) {
+	if query == "" {
+		return time.Time{}, false
+	}
+	for _, format := range searchDateFormats() {
+		if date, err := time.Parse(format, query); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
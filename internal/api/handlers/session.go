@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/api/middleware"
+	"net/http"
+)
+
+// SessionResp - сведения о текущей сессии.
+// ExpiresAt - время истечения JWT-токена (Unix‑время в секундах);
+// отсутствует, если аутентификация отключена (не заданы TODO_PASSWORD и TODO_PASSWORD_HASH).
+type SessionResp struct {
+	Authenticated bool   `json:"authenticated"`
+	ExpiresAt     *int64 `json:"expires_at,omitempty"`
+}
+
+// sessionHandler возвращает сведения о текущей сессии, чтобы клиент мог обновить токен до его истечения.
+// Вызывается через middleware.Auth, поэтому без действительного токена запрос получает 401.
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса.
+func sessionHandler(w http.ResponseWriter, r *http.Request) {
+	resp := SessionResp{Authenticated: true}
+	if claims, ok := middleware.Claims(r); ok {
+		if exp, err := claims.GetExpirationTime(); err == nil && exp != nil {
+			expiresAt := exp.Unix()
+			resp.ExpiresAt = &expiresAt
+		}
+	}
+	api.WriteJSON(w, http.StatusOK, resp)
+}
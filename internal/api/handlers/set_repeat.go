@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// setRepeatIDsLimit - максимальное количество задач в одном запросе на назначение правила повторения.
+const setRepeatIDsLimit = 100
+
+// setRepeatRequest - тело запроса на назначение правила повторения нескольким задачам.
+type setRepeatRequest struct {
+	IDs    []int64 `json:"ids"`
+	Repeat string  `json:"repeat"`
+}
+
+// setRepeatHandler назначает одно правило повторения нескольким задачам (в одной транзакции).
+// Правило проверяется один раз; дата задачи, оказавшаяся в прошлом, пересчитывается по новому правилу.
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - HTTP-запрос с телом {"ids": [1, 2], "repeat": "w 1"}.
+func (s *APIServer) setRepeatHandler(w http.ResponseWriter, r *http.Request) {
+	// Проверяем, что Content-Type начинается с "application/json"
+	if !strings.HasPrefix(strings.TrimSpace(r.Header.Get("Content-Type")), "application/json") {
+		api.WriteJSON(w, http.StatusUnsupportedMediaType, map[string]string{
+			"error": "content type must be application/json",
+		})
+		return
+	}
+
+	var req setRepeatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid JSON payload",
+		})
+		return
+	}
+
+	if len(req.IDs) == 0 {
+		api.WriteValidationError(w, "ids cannot be empty")
+		return
+	}
+	if len(req.IDs) > setRepeatIDsLimit {
+		api.WriteValidationError(w, fmt.Sprintf("too many ids: at most %d allowed", setRepeatIDsLimit))
+		return
+	}
+	if strings.TrimSpace(req.Repeat) == "" {
+		api.WriteValidationError(w, "repeat cannot be empty")
+		return
+	}
+
+	// Проверяем правило один раз для всех задач
+	now := time.Now()
+	today := now.Format(scheduler.DateFormat)
+	if _, err := scheduler.NextDate(now, today, req.Repeat); err != nil {
+		api.WriteValidationError(w, fmt.Sprintf("invalid repeat rule: %v", err))
+		return
+	}
+
+	// Дата в прошлом переносится на ближайшее повторение по новому правилу
+	reschedule := func(date string) (string, error) {
+		// Пустая дата, как и при чтении задачи, означает сегодняшний день
+		if date == "" {
+			return today, nil
+		}
+		if date >= today {
+			return date, nil
+		}
+		return scheduler.NextDate(now, date, req.Repeat)
+	}
+
+	updated, err := db.SetRepeat(s.DB, req.IDs, req.Repeat, reschedule)
+	if err != nil {
+		if errors.Is(err, db.ErrTaskNotFound) {
+			api.WriteJSON(w, http.StatusNotFound, map[string]string{
+				"error": "task not found",
+			})
+			return
+		}
+		// Новая дата нарушает ограничение целостности - возвращаем 409 (Conflict)
+		if errors.Is(err, db.ErrConflict) {
+			api.WriteJSON(w, http.StatusConflict, map[string]string{
+				"error": "task conflicts with an existing task",
+			})
+			return
+		}
+		writeInternalError(w, "failed to update tasks", err)
+		return
+	}
+
+	// Возвращаем количество обновлённых задач
+	api.WriteJSON(w, http.StatusOK, map[string]int64{
+		"updated": updated,
+	})
+}
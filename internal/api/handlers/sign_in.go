@@ -1,15 +1,15 @@
 package handlers
 
 import (
-	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/json"
-	"fmt"
 	"go-task-manager-final_project/config"
 	"go-task-manager-final_project/internal/api"
 	"net/http"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // signInRequest - структура для приёма данных из запроса на авторизацию.
@@ -44,17 +44,17 @@ func handleSignIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Если переменная не задана, возвращаем ошибку 500 (Internal Server Error).
-	if config.Password == "" {
+	// Если ни пароль, ни его хэш не заданы, возвращаем ошибку 500 (Internal Server Error).
+	if !config.AuthEnabled() {
 		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
-			"error": "TODO_PASSWORD environment variable is not set",
+			"error": "TODO_PASSWORD or TODO_PASSWORD_HASH environment variable is not set",
 		})
 		return
 	}
 
-	// Сравниваем пароль из запроса с мастер-паролем.
+	// Сравниваем пароль из запроса с мастер-паролем (или его bcrypt-хэшем).
 	// Если пароли не совпадают, возвращаем ошибку 401 (Unauthorized).
-	if req.Password != config.Password {
+	if !checkPassword(req.Password) {
 		api.WriteJSON(w, http.StatusUnauthorized, map[string]string{
 			"error": "incorrect password",
 		})
@@ -70,36 +70,79 @@ func handleSignIn(w http.ResponseWriter, r *http.Request) {
 	}
 	secret := []byte(config.JWTSecret)
 
-	// Вычисляем хэш пароля с помощью алгоритма SHA-256.
-	hash := sha256.Sum256([]byte(req.Password))
+	// Создаём и подписываем JWT-токен, привязанный к текущим учётным данным (см. config.PasswordFingerprint).
+	// При ошибке подписи возвращаем ошибку 500 (Internal Server Error).
+	signedToken, _, err := signToken(config.PasswordFingerprint(), secret)
+	if err != nil {
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to generate JWT token",
+		})
+		return
+	}
+
+	// Возвращаем успешный ответ 200 (OK) с JWT-токеном в поле "token".
+	api.WriteJSON(w, http.StatusOK, map[string]string{
+		"token": signedToken,
+	})
+
+}
+
+// checkPassword сравнивает пароль с мастер-паролем в активном режиме (см. config.PasswordMode):
+// с bcrypt-хэшем из TODO_PASSWORD_HASH или, для совместимости, с паролем в открытом виде из TODO_PASSWORD.
+// Параметры:
+// password - пароль из запроса.
+// Возвращает: true, если пароль верный.
+func checkPassword(password string) bool {
+	switch config.PasswordMode() {
+	case config.PasswordModeBcrypt:
+		return bcrypt.CompareHashAndPassword([]byte(config.PasswordHash), []byte(password)) == nil
+	case config.PasswordModePlain:
+		return subtle.ConstantTimeCompare([]byte(password), []byte(config.Password)) == 1
+	default:
+		return false
+	}
+}
+
+// defaultTokenLifetime - время жизни JWT-токена с момента выдачи (или обновления) по умолчанию.
+const defaultTokenLifetime = 8 * time.Hour
+
+// getTokenLifetime возвращает время жизни JWT-токена из переменной окружения TODO_JWT_TTL.
+// Если значение не задано или некорректно (не длительность Go или не положительное), используется defaultTokenLifetime.
+func getTokenLifetime() time.Duration {
+	ttl, err := time.ParseDuration(config.JWTTTL)
+	if err != nil || ttl <= 0 {
+		return defaultTokenLifetime
+	}
+	return ttl
+}
+
+// signToken создаёт JWT-токен и подписывает его секретом.
+// Параметры:
+// passwordHash - отпечаток учётных данных (см. config.PasswordFingerprint);
+// secret - секрет для подписи (из TODO_JWT_SECRET).
+// Возвращает:
+// подписанный токен, время его истечения и ошибку подписи.
+func signToken(passwordHash string, secret []byte) (string, time.Time, error) {
+	expiresAt := time.Now().Add(getTokenLifetime())
 
 	// Формируем claims (полезную нагрузку) JWT-токена:
 	// - "authenticated": флаг успешной аутентификации (true).
-	// - "exp": время истечения токена (текущее время + 8 часов).
+	// - "exp": время истечения токена (текущее время + время жизни из getTokenLifetime).
 	// - "iss": идентификатор сервера-издателя токена.
-	// - "password_hash": шестнадцатеричное представление хэша пароля.
+	// - "password_hash": отпечаток учётных данных (SHA-256 пароля или его bcrypt-хэша).
 	claims := jwt.MapClaims{
 		"authenticated": true,
-		"exp":           time.Now().Add(time.Hour * 8).Unix(),
+		"exp":           expiresAt.Unix(),
 		"iss":           "go-task-manager-final_project",
-		"password_hash": fmt.Sprintf("%x", hash),
+		"password_hash": passwordHash,
 	}
 
 	// Создаём JWT-токен с указанными claims и алгоритмом подписи HS256.
 	// Подписываем токен секретом и получаем его строковое представление.
-	// При ошибке подписи возвращаем ошибку 500 (Internal Server Error).
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	signedToken, err := token.SignedString(secret)
 	if err != nil {
-		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
-			"error": "failed to generate JWT token",
-		})
-		return
+		return "", time.Time{}, err
 	}
-
-	// Возвращаем успешный ответ 200 (OK) с JWT-токеном в поле "token".
-	api.WriteJSON(w, http.StatusOK, map[string]string{
-		"token": signedToken,
-	})
-
+	return signedToken, expiresAt, nil
 }
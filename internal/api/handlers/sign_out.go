@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"go-task-manager-final_project/internal/api"
+	"net/http"
+)
+
+// handleSignOut - обработчик HTTP-запроса на выход пользователя.
+// Сбрасывает cookie "token": пустое значение и MaxAge=-1 заставляют браузер удалить её.
+// Токен на стороне сервера не хранится, поэтому аутентификация не требуется; уже выданный токен
+// (например, переданный в заголовке Authorization) остаётся действительным до истечения срока.
+// Параметры:
+// w - объект http.ResponseWriter для отправки ответа клиенту.
+// r - объект *http.Request с данными запроса.
+func handleSignOut(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:   "token",
+		Value:  "",
+		Path:   "/",
+		MaxAge: -1,
+	})
+
+	// Возвращаем успешный ответ 200 (OK) с пустым JSON-объектом
+	api.WriteJSON(w, http.StatusOK, map[string]interface{}{})
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"go-task-manager-final_project/config"
+	"strconv"
+	"unicode"
+)
+
+// defaultSnippetLength - длина фрагмента комментария в результатах поиска по умолчанию (в символах).
+const defaultSnippetLength = 160
+
+// Маркеры, которыми во фрагменте выделяется совпадение, и признак обрезанного текста.
+const (
+	snippetMarkStart = "[["
+	snippetMarkEnd   = "]]"
+	snippetEllipsis  = "…"
+)
+
+// getSnippetLength возвращает длину фрагмента из переменной окружения TODO_SNIPPET_LENGTH.
+// Если значение не задано или некорректно (не положительное целое), используется defaultSnippetLength.
+func getSnippetLength() int {
+	length, err := strconv.Atoi(config.SnippetLength)
+	if err != nil || length <= 0 {
+		return defaultSnippetLength
+	}
+	return length
+}
+
+// lowerRunes приводит символы к нижнему регистру посимвольно, сохраняя позиции символов.
+func lowerRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+// indexRunes возвращает позицию первого вхождения needle в haystack или -1.
+func indexRunes(haystack, needle []rune) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		match := true
+		for j := range needle {
+			if haystack[i+j] != needle[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// makeSnippet возвращает фрагмент текста длиной не более length символов вокруг первого совпадения
+// с одним из терминов (без учёта регистра). Совпадение выделяется маркерами snippetMarkStart/snippetMarkEnd,
+// обрезанные края отмечаются snippetEllipsis. Если совпадений нет, возвращается начало текста.
+// Параметры:
+// text - исходный текст (комментарий задачи);
+// terms - термины поискового запроса;
+// length - максимальная длина фрагмента в символах (без маркеров и многоточий).
+func makeSnippet(text string, terms []string, length int) string {
+	runes := []rune(text)
+	lowered := lowerRunes(text)
+
+	// Ищем самое раннее совпадение среди терминов
+	matchStart, matchLen := -1, 0
+	for _, term := range terms {
+		needle := lowerRunes(term)
+		if len(needle) == 0 {
+			continue
+		}
+		if pos := indexRunes(lowered, needle); pos >= 0 && (matchStart < 0 || pos < matchStart) {
+			matchStart, matchLen = pos, len(needle)
+		}
+	}
+
+	if matchStart < 0 {
+		if len(runes) <= length {
+			return text
+		}
+		return string(runes[:length]) + snippetEllipsis
+	}
+	if matchLen > length {
+		matchLen = length
+	}
+
+	// Центрируем окно на совпадении и сдвигаем его в границы текста
+	start := matchStart - (length-matchLen)/2
+	if start+length > len(runes) {
+		start = len(runes) - length
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + length
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	snippet := string(runes[start:matchStart]) +
+		snippetMarkStart + string(runes[matchStart:matchStart+matchLen]) + snippetMarkEnd +
+		string(runes[matchStart+matchLen:end])
+	if start > 0 {
+		snippet = snippetEllipsis + snippet
+	}
+	if end < len(runes) {
+		snippet += snippetEllipsis
+	}
+	return snippet
+}
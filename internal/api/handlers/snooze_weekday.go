@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// snoozeWeekdayHandler переносит задачу на ближайший указанный день недели после сегодняшнего
+// (например, weekday=1 - на следующий понедельник, см. scheduler.NextWeekday).
+// "Сегодня" определяется в локальном часовом поясе сервера (переменная окружения TZ).
+// Правило повторения задачи не меняется.
+// Параметры:
+// w - http.ResponseWriter для отправки ответа клиенту;
+// r - *http.Request с параметрами id и weekday (1 - понедельник, ..., 7 - воскресенье).
+func (s *APIServer) snoozeWeekdayHandler(w http.ResponseWriter, r *http.Request) {
+	// Получаем параметр id из строки запроса
+	id := r.URL.Query().Get("id")
+
+	// Проверяем, что ID не пустой и не состоит только из пробелов
+	if strings.TrimSpace(id) == "" {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "id parameter required",
+		})
+		return
+	}
+
+	// Проверяем формат ID (числовой)
+	if _, err := strconv.Atoi(id); err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid id format: must be a integer number",
+		})
+		return
+	}
+
+	// Проверяем день недели
+	weekday, err := strconv.Atoi(r.URL.Query().Get("weekday"))
+	if err != nil || weekday < 1 || weekday > 7 {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "weekday must be an integer in range [1, 7]",
+		})
+		return
+	}
+
+	// Проверяем, что задача существует
+	if _, err := db.GetTask(s.DB, id); err != nil {
+		if errors.Is(err, db.ErrTaskNotFound) {
+			api.WriteJSON(w, http.StatusNotFound, map[string]string{
+				"error": "task not found",
+			})
+		} else {
+			api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+				"error": "could not retrieve task from database",
+			})
+		}
+		return
+	}
+
+	next, err := scheduler.NextWeekday(time.Now(), weekday)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := db.UpdateDate(s.DB, next, id); err != nil {
+		// Новая дата нарушает ограничение целостности - возвращаем 409 (Conflict)
+		if errors.Is(err, db.ErrConflict) {
+			api.WriteJSON(w, http.StatusConflict, map[string]string{
+				"error": "task conflicts with an existing task",
+			})
+			return
+		}
+		writeInternalError(w, "could not update task date", err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, map[string]string{
+		"date": next,
+	})
+}
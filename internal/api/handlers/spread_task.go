@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// spreadDatesLimit - максимальное количество дат в одном запросе на создание копий задачи.
+const spreadDatesLimit = 100
+
+// spreadTaskRequest - тело запроса на создание копий задачи на указанные даты.
+type spreadTaskRequest struct {
+	Title   string   `json:"title"`
+	Comment string   `json:"comment"`
+	Dates   []string `json:"dates"`
+}
+
+// Режимы обработки списка дат (параметр mode).
+const (
+	spreadModeTransactional = "transactional" // все задачи создаются в одной транзакции (по умолчанию)
+	spreadModeBestEffort    = "besteffort"    // каждая задача создаётся независимо, ошибки собираются в отчёт
+)
+
+// SpreadItemResult - результат создания задачи на одну дату в режиме besteffort.
+// Index и Date - позиция и значение даты в запросе; ID - идентификатор созданной задачи (при успехе);
+// Error - причина ошибки (при неудаче).
+type SpreadItemResult struct {
+	Index int    `json:"index"`
+	Date  string `json:"date"`
+	ID    int64  `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// SpreadReportResp - ответ в режиме besteffort: результаты по каждой дате и итоговые счётчики.
+type SpreadReportResp struct {
+	Results   []SpreadItemResult `json:"results"`
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+}
+
+// spreadTask проверяет дату и готовит копию задачи на неё.
+// Параметры:
+// req - запрос на создание копий;
+// date - дата копии.
+// Возвращает задачу и ошибку, если дата некорректна.
+func spreadTask(req spreadTaskRequest, date string) (*db.Task, error) {
+	if _, err := time.Parse(scheduler.DateFormat, date); err != nil {
+		return nil, fmt.Errorf("invalid date %q: expected format %s", date, scheduler.DateFormat)
+	}
+	task := &db.Task{Date: date, Title: req.Title, Comment: req.Comment}
+	applyDefaultComment(task)
+	// Дата проходит ту же проверку, что и при обычном создании задачи
+	if err := checkDate(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// spreadTaskHandler создаёт по одной задаче на каждую из указанных дат (в одной транзакции).
+// В отличие от правила повторения, даты задаются явным списком.
+// При mode=besteffort каждая задача создаётся независимо: ошибки по отдельным датам не отменяют остальные,
+// а в ответе возвращается результат по каждой дате (см. SpreadReportResp).
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - HTTP-запрос с телом {"title": "...", "comment": "...", "dates": ["20240201", ...]}.
+func (s *APIServer) spreadTaskHandler(w http.ResponseWriter, r *http.Request) {
+	// Проверяем, что Content-Type начинается с "application/json"
+	if !strings.HasPrefix(strings.TrimSpace(r.Header.Get("Content-Type")), "application/json") {
+		api.WriteJSON(w, http.StatusUnsupportedMediaType, map[string]string{
+			"error": "content type must be application/json",
+		})
+		return
+	}
+
+	// Читаем тело запроса, отклоняя некорректный UTF-8 до сохранения в БД
+	body, err := readUTF8Body(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = spreadModeTransactional
+	}
+	if mode != spreadModeTransactional && mode != spreadModeBestEffort {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "unsupported mode: expected \"transactional\" or \"besteffort\"",
+		})
+		return
+	}
+
+	var req spreadTaskRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid JSON payload",
+		})
+		return
+	}
+
+	if strings.TrimSpace(req.Title) == "" {
+		api.WriteValidationError(w, "title cannot be empty")
+		return
+	}
+	if len(req.Dates) == 0 {
+		api.WriteValidationError(w, "dates cannot be empty")
+		return
+	}
+	if len(req.Dates) > spreadDatesLimit {
+		api.WriteValidationError(w, fmt.Sprintf("too many dates: at most %d allowed", spreadDatesLimit))
+		return
+	}
+
+	if mode == spreadModeBestEffort {
+		s.spreadBestEffort(w, req)
+		return
+	}
+
+	// Проверяем каждую дату и готовим копии задачи
+	tasks := make([]*db.Task, 0, len(req.Dates))
+	for i, date := range req.Dates {
+		task, err := spreadTask(req, date)
+		if err != nil {
+			api.WriteValidationError(w, fmt.Sprintf("dates[%d]: %v", i, err))
+			return
+		}
+		tasks = append(tasks, task)
+	}
+
+	ids, err := db.AddTasks(s.DB, tasks)
+	if err != nil {
+		// Нарушение ограничения целостности (например, уникального индекса) - возвращаем 409 (Conflict)
+		if errors.Is(err, db.ErrConflict) {
+			api.WriteJSON(w, http.StatusConflict, map[string]string{
+				"error": "task conflicts with an existing task",
+			})
+			return
+		}
+		log.Printf("failed to save spread tasks: %v", err)
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to save tasks",
+		})
+		return
+	}
+
+	api.WriteJSON(w, http.StatusCreated, map[string][]int64{
+		"ids": ids,
+	})
+}
+
+// spreadBestEffort создаёт задачи по одной, без общей транзакции, и отправляет отчёт по каждой дате.
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// req - проверенный запрос на создание копий.
+func (s *APIServer) spreadBestEffort(w http.ResponseWriter, req spreadTaskRequest) {
+	resp := SpreadReportResp{Results: make([]SpreadItemResult, 0, len(req.Dates))}
+	for i, date := range req.Dates {
+		result := SpreadItemResult{Index: i, Date: date}
+
+		task, err := spreadTask(req, date)
+		if err == nil {
+			result.ID, err = db.AddTask(s.DB, task)
+			if errors.Is(err, db.ErrConflict) {
+				err = errors.New("task conflicts with an existing task")
+			} else if err != nil {
+				log.Printf("failed to save spread task for %s: %v", date, err)
+				err = errors.New("failed to save task")
+			}
+		}
+
+		if err != nil {
+			result.Error = err.Error()
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+
+	api.WriteJSON(w, http.StatusOK, resp)
+}
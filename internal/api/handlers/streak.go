@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// streakHandler обрабатывает запрос на вычисление серий своевременного выполнения повторяющейся задачи.
+// Возвращает текущую (current) и максимальную (longest) серии.
+// Параметры:
+// w - http.ResponseWriter для отправки ответа клиенту;
+// r - *http.Request, входящий HTTP-запрос.
+func (s *APIServer) streakHandler(w http.ResponseWriter, r *http.Request) {
+	// Получаем параметр id из строки запроса
+	id := r.URL.Query().Get("id")
+
+	// Проверяем, что ID не пустой и не состоит только из пробелов
+	if strings.TrimSpace(id) == "" {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "id parameter required",
+		})
+		return
+	}
+
+	// Проверяем формат ID (числовой)
+	if _, err := strconv.Atoi(id); err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid id format: must be a integer number",
+		})
+		return
+	}
+
+	// Получаем задачу из базы данных по указанному ID
+	task, err := db.GetTask(s.DB, id)
+	if err != nil {
+		if errors.Is(err, db.ErrTaskNotFound) {
+			api.WriteJSON(w, http.StatusNotFound, map[string]string{
+				"error": "task not found",
+			})
+		} else {
+			api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+				"error": "could not retrieve task from database",
+			})
+		}
+		return
+	}
+
+	// Серии имеют смысл только для повторяющихся задач
+	if task.Repeat == "" {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "task is not recurring",
+		})
+		return
+	}
+
+	// Получаем историю выполнений задачи
+	history, err := db.GetCompletions(s.DB, id)
+	if err != nil {
+		log.Printf("failed to fetch completions for task %s: %v", id, err)
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "could not retrieve completions from database",
+		})
+		return
+	}
+
+	completions := make([]scheduler.Completion, 0, len(history))
+	for _, c := range history {
+		completions = append(completions, scheduler.Completion{
+			Scheduled: c.ScheduledDate,
+			Completed: c.CompletedAt.Format(scheduler.DateFormat),
+		})
+	}
+
+	current, longest, err := scheduler.Streaks(task.Repeat, completions, task.Date, time.Now())
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid repeat pattern: " + err.Error(),
+		})
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, map[string]int{
+		"current": current,
+		"longest": longest,
+	})
+}
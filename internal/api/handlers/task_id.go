@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// taskIDParam возвращает идентификатор задачи из пути запроса (/api/task/{id}),
+// а если его там нет - из параметра строки запроса id (/api/task?id=...).
+// Параметры:
+// r - HTTP-запрос.
+// Возвращает: идентификатор задачи (пустую строку, если он не передан).
+func taskIDParam(r *http.Request) string {
+	if id := chi.URLParam(r, "id"); id != "" {
+		return id
+	}
+	return r.URL.Query().Get("id")
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// taskNextDateHandler возвращает ближайшую дату задачи, ничего не изменяя в базе данных.
+// Для разовой задачи, а также для задачи с датой не раньше сегодняшней возвращается её собственная дата;
+// для просроченной повторяющейся задачи - следующая дата по правилу повторения после сегодняшнего дня.
+// Параметры:
+// w - http.ResponseWriter для отправки ответа клиенту;
+// r - *http.Request с параметром id.
+func (s *APIServer) taskNextDateHandler(w http.ResponseWriter, r *http.Request) {
+	// Получаем параметр id из строки запроса
+	id := r.URL.Query().Get("id")
+
+	// Проверяем, что ID не пустой и не состоит только из пробелов
+	if strings.TrimSpace(id) == "" {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "id parameter required",
+		})
+		return
+	}
+
+	// Проверяем формат ID (числовой)
+	if _, err := strconv.Atoi(id); err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid id format: must be a integer number",
+		})
+		return
+	}
+
+	// Получаем задачу из базы данных по указанному ID
+	task, err := db.GetTask(s.DB, id)
+	if err != nil {
+		if errors.Is(err, db.ErrTaskNotFound) {
+			api.WriteJSON(w, http.StatusNotFound, map[string]string{
+				"error": "task not found",
+			})
+		} else {
+			api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+				"error": "could not retrieve task from database",
+			})
+		}
+		return
+	}
+
+	now := time.Now()
+	next := task.Date
+	if task.Repeat != "" && task.Date < now.Format(scheduler.DateFormat) {
+		next, err = scheduler.NextDate(now, task.Date, task.Repeat)
+		if err != nil {
+			api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+				"error": "invalid repeat pattern: " + err.Error(),
+			})
+			return
+		}
+	}
+
+	api.WriteJSON(w, http.StatusOK, map[string]string{
+		"date": next,
+	})
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// taskPrevDateHandler возвращает последнее повторение задачи не позже сегодняшнего дня (см. scheduler.PrevDate).
+// Если дата задачи ещё не наступила, предыдущего повторения нет - в ответе "date": null.
+// Параметры:
+// w - http.ResponseWriter для отправки ответа клиенту;
+// r - *http.Request с параметром id.
+func (s *APIServer) taskPrevDateHandler(w http.ResponseWriter, r *http.Request) {
+	// Получаем параметр id из строки запроса
+	id := r.URL.Query().Get("id")
+
+	// Проверяем, что ID не пустой и не состоит только из пробелов
+	if strings.TrimSpace(id) == "" {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "id parameter required",
+		})
+		return
+	}
+
+	// Проверяем формат ID (числовой)
+	if _, err := strconv.Atoi(id); err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid id format: must be a integer number",
+		})
+		return
+	}
+
+	// Получаем задачу из базы данных по указанному ID
+	task, err := db.GetTask(s.DB, id)
+	if err != nil {
+		if errors.Is(err, db.ErrTaskNotFound) {
+			api.WriteJSON(w, http.StatusNotFound, map[string]string{
+				"error": "task not found",
+			})
+		} else {
+			api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+				"error": "could not retrieve task from database",
+			})
+		}
+		return
+	}
+
+	prev, err := scheduler.PrevDate(time.Now(), task.Date, task.Repeat)
+	if errors.Is(err, scheduler.ErrNoPrevDate) {
+		api.WriteJSON(w, http.StatusOK, map[string]*string{
+			"date": nil,
+		})
+		return
+	}
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "invalid repeat pattern: " + err.Error(),
+		})
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, map[string]string{
+		"date": prev,
+	})
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"fmt"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"net/http"
+	"strconv"
+)
+
+// byMonthTaskLimit - максимальное количество задач за год в ответе.
+const byMonthTaskLimit = 1000
+
+// tasksByMonthHandler - обработчик HTTP-запроса для получения задач за год, сгруппированных по месяцам.
+// Ответ имеет вид {"2024-01": [...], "2024-02": [...]}. Месяцы без задач включаются только при include_empty=true.
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса с параметрами year и include_empty.
+func (s *APIServer) tasksByMonthHandler(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(r.URL.Query().Get("year"))
+	if err != nil || year < 1 || year > 9999 {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": "year must be an integer in range [1, 9999]",
+		})
+		return
+	}
+
+	includeEmpty := false
+	if value := r.URL.Query().Get("include_empty"); value != "" {
+		if includeEmpty, err = strconv.ParseBool(value); err != nil {
+			api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+				"error": "include_empty must be a boolean",
+			})
+			return
+		}
+	}
+
+	// Получаем формат дат для ответа (параметр date_format)
+	dateFormat, err := responseDateFormat(r)
+	if err != nil {
+		api.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	// Даты хранятся строками YYYYMMDD, поэтому год - это лексикографический диапазон
+	tasks, err := db.GetTasksBetween(s.DB, fmt.Sprintf("%04d0101", year), fmt.Sprintf("%04d1231", year), byMonthTaskLimit)
+	if err != nil {
+		api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+			"error": "failed to fetch tasks from database",
+		})
+		return
+	}
+
+	months := make(map[string][]*db.Task)
+	if includeEmpty {
+		for month := 1; month <= 12; month++ {
+			months[fmt.Sprintf("%04d-%02d", year, month)] = []*db.Task{}
+		}
+	}
+	// Группируем по префиксу YYYYMM даты (до перевода дат в формат ответа)
+	for _, task := range tasks {
+		key := task.Date[:4] + "-" + task.Date[4:6]
+		months[key] = append(months[key], task)
+	}
+	formatTaskDates(dateFormat, tasks...)
+
+	api.WriteJSON(w, http.StatusOK, months)
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"unicode/utf8"
+
+	"go-task-manager-final_project/internal/db"
+)
+
+// errInvalidUTF8 - ошибка для текста задачи, не являющегося корректным UTF-8.
+var errInvalidUTF8 = errors.New("title and comment must be valid UTF-8")
+
+// readUTF8Body читает тело запроса и проверяет, что оно является корректным UTF-8.
+// Проверка выполняется до декодирования JSON: encoding/json молча заменяет некорректные
+// байты символом U+FFFD, и после декодирования испорченный текст уже не отличить от корректного.
+// Параметры:
+// r - HTTP-запрос.
+// Возвращает: тело запроса и ошибку чтения или errInvalidUTF8.
+func readUTF8Body(r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if !utf8.Valid(body) {
+		return nil, errInvalidUTF8
+	}
+	return body, nil
+}
+
+// checkTaskText проверяет, что заголовок и комментарий задачи - корректный UTF-8.
+// Параметры:
+// task - проверяемая задача.
+// Возвращает: errInvalidUTF8, если текст некорректен, иначе nil.
+func checkTaskText(task *db.Task) error {
+	if !utf8.ValidString(task.Title) || !utf8.ValidString(task.Comment) {
+		return errInvalidUTF8
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"go-task-manager-final_project/config"
+	"go-task-manager-final_project/internal/api"
+	"go-task-manager-final_project/internal/db"
+	"net/http"
+	"strconv"
+)
+
+// DBSizeResp - размер БД в байтах и количество задач.
+type DBSizeResp struct {
+	SizeBytes int64 `json:"size_bytes"`
+	Tasks     int64 `json:"tasks"`
+}
+
+// VacuumResp - размер БД в байтах до и после VACUUM.
+type VacuumResp struct {
+	SizeBefore int64 `json:"size_before"`
+	SizeAfter  int64 `json:"size_after"`
+}
+
+// adminEndpointsEnabled сообщает, включены ли административные эндпоинты обслуживания БД
+// (переменная окружения TODO_ADMIN_ENDPOINTS).
+// По умолчанию (значение не задано или некорректно) выключены.
+func adminEndpointsEnabled() bool {
+	enabled, _ := strconv.ParseBool(config.AdminEndpoints)
+	return enabled
+}
+
+// adminOnly оборачивает административный обработчик: при выключенных административных эндпоинтах
+// (см. adminEndpointsEnabled) запрос получает 403 (Forbidden). Проверка не зависит от аутентификации:
+// без пароля middleware.Auth пропускает все запросы, а эндпоинты должны оставаться закрытыми.
+// Параметры:
+// next - административный обработчик.
+// Возвращает: обработчик с проверкой флага.
+func adminOnly(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !adminEndpointsEnabled() {
+			api.WriteJSON(w, http.StatusForbidden, map[string]string{
+				"error": "admin endpoints are disabled: set TODO_ADMIN_ENDPOINTS=true to enable",
+			})
+			return
+		}
+		next(w, r)
+	}
+}
+
+// dbSizeHandler возвращает текущий размер БД и количество задач (включая архивные).
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса.
+func (s *APIServer) dbSizeHandler(w http.ResponseWriter, r *http.Request) {
+	size, err := db.Size(s.DB)
+	if err != nil {
+		writeInternalError(w, "could not read database size", err)
+		return
+	}
+	count, err := db.CountTasks(s.DB)
+	if err != nil {
+		writeInternalError(w, "could not count tasks", err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, DBSizeResp{
+		SizeBytes: size,
+		Tasks:     count,
+	})
+}
+
+// vacuumHandler выполняет VACUUM и возвращает размер БД до и после.
+// Регистрируется через adminOnly (доступен только при TODO_ADMIN_ENDPOINTS=true):
+// VACUUM перестраивает весь файл и на время работы блокирует запись.
+// Параметры:
+// w - объект для записи HTTP-ответа;
+// r - объект HTTP-запроса.
+func (s *APIServer) vacuumHandler(w http.ResponseWriter, r *http.Request) {
+	before, err := db.Size(s.DB)
+	if err != nil {
+		writeInternalError(w, "could not read database size", err)
+		return
+	}
+	if err = db.Vacuum(s.DB); err != nil {
+		writeInternalError(w, "could not vacuum database", err)
+		return
+	}
+	after, err := db.Size(s.DB)
+	if err != nil {
+		writeInternalError(w, "could not read database size", err)
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, VacuumResp{
+		SizeBefore: before,
+		SizeAfter:  after,
+	})
+}
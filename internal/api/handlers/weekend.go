@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"fmt"
+	"go-task-manager-final_project/config"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Значения переменной окружения TODO_NO_WEEKEND_TASKS.
+const (
+	weekendReject      = "reject"       // задача на выходной отклоняется (422)
+	weekendRollForward = "roll_forward" // дата задачи переносится на ближайший понедельник
+	weekendAllow       = ""             // задачи на выходные разрешены (по умолчанию)
+)
+
+// weekendPolicy возвращает политику для задач на выходные из переменной окружения TODO_NO_WEEKEND_TASKS.
+// "reject" (или любое истинное значение, например "true") - отклонять такие задачи,
+// "roll_forward" - переносить их на понедельник; остальные значения разрешают задачи на выходные.
+func weekendPolicy() string {
+	value := strings.ToLower(strings.TrimSpace(config.NoWeekendTasks))
+	if value == weekendRollForward || value == weekendReject {
+		return value
+	}
+	if enabled, err := strconv.ParseBool(value); err == nil && enabled {
+		return weekendReject
+	}
+	return weekendAllow
+}
+
+// checkWeekend применяет политику TODO_NO_WEEKEND_TASKS к уже скорректированной дате задачи.
+// Параметры:
+// task - задача с датой в формате scheduler.DateFormat.
+// Возвращает: ошибку, если дата приходится на субботу или воскресенье и такие задачи запрещены.
+func checkWeekend(task *db.Task) error {
+	policy := weekendPolicy()
+	if policy == weekendAllow {
+		return nil
+	}
+
+	date, err := time.Parse(scheduler.DateFormat, task.Date)
+	if err != nil {
+		return err
+	}
+	if date.Weekday() != time.Saturday && date.Weekday() != time.Sunday {
+		return nil
+	}
+
+	if policy == weekendReject {
+		return fmt.Errorf("date %s falls on a weekend: weekend tasks are not allowed", task.Date)
+	}
+	// Переносим на ближайший понедельник
+	for date.Weekday() != time.Monday {
+		date = date.AddDate(0, 0, 1)
+	}
+	task.Date = date.Format(scheduler.DateFormat)
+	return nil
+}
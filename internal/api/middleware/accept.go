@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"go-task-manager-final_project/internal/api"
+	"net/http"
+	"strings"
+)
+
+// Accept - middleware для проверки заголовка Accept (согласование типа содержимого).
+// Если клиент не принимает ни один из поддерживаемых типов, возвращает 406 (Not Acceptable)
+// со списком поддерживаемых типов. Отсутствующий заголовок Accept или "*/*" допускают любой тип.
+// Параметр:
+// supported - поддерживаемые эндпоинтом типы содержимого (например, "application/json").
+// Возвращает:
+// функцию, оборачивающую обработчик проверкой заголовка Accept.
+func Accept(supported ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accept := r.Header.Get("Accept")
+			if accept != "" && !acceptsAny(accept, supported) {
+				api.WriteJSON(w, http.StatusNotAcceptable, map[string]any{
+					"error":     "none of the requested media types can be served",
+					"supported": supported,
+				})
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// acceptsAny проверяет, допускает ли значение заголовка Accept хотя бы один из поддерживаемых типов.
+// Учитывает шаблоны "*/*" и "type/*", а также исключение типа через параметр q=0.
+func acceptsAny(accept string, supported []string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		// Отделяем тип от параметров (например, "application/json;q=0.9")
+		params := strings.Split(part, ";")
+		mediaRange := strings.ToLower(strings.TrimSpace(params[0]))
+		if mediaRange == "" || rejected(params[1:]) {
+			continue
+		}
+
+		for _, mediaType := range supported {
+			if matchesMediaRange(mediaRange, strings.ToLower(mediaType)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rejected проверяет, задан ли для типа параметр q=0 (клиент явно отказывается от этого типа).
+func rejected(params []string) bool {
+	for _, p := range params {
+		name, value, ok := strings.Cut(strings.TrimSpace(p), "=")
+		if ok && strings.EqualFold(name, "q") && strings.Trim(strings.TrimSpace(value), "0.") == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMediaRange проверяет соответствие типа содержимого диапазону из заголовка Accept.
+func matchesMediaRange(mediaRange, mediaType string) bool {
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+	// Диапазон вида "application/*"
+	if prefix, ok := strings.CutSuffix(mediaRange, "/*"); ok {
+		return strings.HasPrefix(mediaType, prefix+"/")
+	}
+	return false
+}
@@ -1,16 +1,46 @@
 package middleware
 
 import (
-	"crypto/sha256"
+	"context"
 	"fmt"
 	"go-task-manager-final_project/config"
 	"go-task-manager-final_project/internal/api"
 	"net/http"
+	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// claimsKey - ключ контекста запроса, под которым Auth сохраняет claims проверенного JWT-токена.
+type claimsKey struct{}
+
+// Claims возвращает claims JWT-токена, проверенного middleware Auth для этого запроса.
+// Возвращает false, если токен не проверялся (например, аутентификация отключена).
+func Claims(r *http.Request) (jwt.MapClaims, bool) {
+	claims, ok := r.Context().Value(claimsKey{}).(jwt.MapClaims)
+	return claims, ok
+}
+
+// tokenFromRequest извлекает JWT-токен из запроса: из заголовка "Authorization: Bearer <token>",
+// а если такого заголовка нет - из cookie "token". Заголовок имеет приоритет над cookie;
+// заголовок Authorization с другой схемой (например, Basic) не учитывается.
+// Параметры:
+// r - объект HTTP-запроса.
+// Возвращает: токен и false, если токен не передан.
+func tokenFromRequest(r *http.Request) (string, bool) {
+	if scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " "); found && strings.EqualFold(scheme, "Bearer") {
+		token = strings.TrimSpace(token)
+		return token, token != ""
+	}
+	cookie, err := r.Cookie("token")
+	if err != nil {
+		return "", false
+	}
+	return cookie.Value, true
+}
+
 // Auth - middleware-функция для проверки авторизации пользователя через JWT-токен.
+// Токен передаётся в заголовке "Authorization: Bearer <token>" или в cookie "token" (см. tokenFromRequest).
 // Параметр:
 // next - обработчик HTTP-запроса, который будет вызван при успешной авторизации.
 // Возвращает:
@@ -18,12 +48,12 @@ import (
 func Auth(next http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
-		// Если пароль задан, выполняем проверку авторизации.
-		if config.Password != "" {
-			// Пытаемся получить cookie с именем "token" из запроса.
-			cookie, err := r.Cookie("token")
-			if err != nil {
-				// Если cookie отсутствует или возникла ошибка - возвращаем статус 401 (Неавторизован).
+		// Если пароль (или его хэш) задан, выполняем проверку авторизации.
+		if config.AuthEnabled() {
+			// Пытаемся получить токен из заголовка Authorization или cookie "token".
+			tokenString, ok := tokenFromRequest(r)
+			if !ok {
+				// Если токен отсутствует или заголовок некорректен - возвращаем статус 401 (Неавторизован).
 				api.WriteJSON(w, http.StatusUnauthorized, map[string]string{
 					"error": "unauthorized",
 				})
@@ -39,8 +69,8 @@ func Auth(next http.HandlerFunc) http.HandlerFunc {
 			}
 			secret := []byte(config.JWTSecret)
 
-			// Парсим JWT-токен из значения cookie.
-			token, err := jwt.Parse(cookie.Value, func(token *jwt.Token) (interface{}, error) {
+			// Парсим JWT-токен.
+			token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 				// Проверяем, что алгоритм подписи токена соответствует ожидаемому (HMAC).
 				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 					return nil, fmt.Errorf("unexpected signing method %q", token.Header["alg"])
@@ -66,19 +96,17 @@ func Auth(next http.HandlerFunc) http.HandlerFunc {
 				return
 			}
 
-			// Вычисляем SHA-256 хэш текущего пароля из окружения.
-			currentHash := sha256.Sum256([]byte(config.Password))
-			currentHashStr := fmt.Sprintf("%x", currentHash)
-
-			// Сравниваем хэш пароля из токена с текущим хэшем пароля.
-			// Если хэши не совпадают - токен недействителен.
-			if claims["password_hash"] != currentHashStr {
+			// Сравниваем отпечаток учётных данных из токена с текущим (см. config.PasswordFingerprint).
+			// Если они не совпадают - пароль сменился и токен недействителен.
+			if claims["password_hash"] != config.PasswordFingerprint() {
 				api.WriteJSON(w, http.StatusUnauthorized, map[string]string{
 					"error": "invalid token: password changed",
 				})
 				return
 			}
 
+			// Сохраняем claims в контексте запроса для обработчиков (например, сведений о сессии)
+			r = r.WithContext(context.WithValue(r.Context(), claimsKey{}, claims))
 		}
 		// Если все проверки прошли - передаём запрос дальше по цепочке обработчиков.
 		next(w, r)
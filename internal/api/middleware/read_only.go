@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"go-task-manager-final_project/internal/api"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// readOnly хранит состояние режима "только чтение" (обслуживание).
+// Переключается во время работы сервера, поэтому используется атомарная переменная.
+var readOnly atomic.Bool
+
+// Maintenance - сведения об окне обслуживания, сообщаемые клиентам в ответе 503.
+// Until - ожидаемое время возобновления записи (нулевое, если не задано);
+// Message - пояснение для пользователей (может быть пустым).
+type Maintenance struct {
+	Until   time.Time
+	Message string
+}
+
+// maintenance хранит сведения об окне обслуживания для текущего режима "только чтение".
+var maintenance atomic.Pointer[Maintenance]
+
+// SetReadOnly включает или выключает режим "только чтение".
+// Сведения об окне обслуживания при этом сбрасываются.
+func SetReadOnly(enabled bool) {
+	SetMaintenance(enabled, Maintenance{})
+}
+
+// SetMaintenance включает или выключает режим "только чтение" вместе со сведениями об окне обслуживания.
+// При выключении режима сведения сбрасываются.
+// Параметры:
+// enabled - включить ли режим;
+// info - время возобновления и сообщение для клиентов.
+func SetMaintenance(enabled bool, info Maintenance) {
+	if !enabled {
+		info = Maintenance{}
+	}
+	maintenance.Store(&info)
+	readOnly.Store(enabled)
+}
+
+// IsReadOnly сообщает, включён ли режим "только чтение".
+func IsReadOnly() bool {
+	return readOnly.Load()
+}
+
+// CurrentMaintenance возвращает сведения о текущем окне обслуживания.
+func CurrentMaintenance() Maintenance {
+	if info := maintenance.Load(); info != nil {
+		return *info
+	}
+	return Maintenance{}
+}
+
+// ReadOnly - middleware-функция для эндпоинтов, изменяющих данные.
+// В режиме "только чтение" отклоняет запрос со статусом 503 (Service Unavailable).
+// Если задано окно обслуживания, в ответ добавляются maintenance_until (RFC 3339) и message,
+// а также заголовок Retry-After с количеством секунд до возобновления записи.
+// Параметр:
+// next - обработчик HTTP-запроса, который будет вызван, если режим выключен.
+// Возвращает:
+// http.HandlerFunc - обернутый обработчик с проверкой режима.
+func ReadOnly(next http.HandlerFunc) http.HandlerFunc {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if IsReadOnly() {
+			resp := map[string]string{
+				"error": "service in read-only mode",
+			}
+			info := CurrentMaintenance()
+			if !info.Until.IsZero() {
+				resp["maintenance_until"] = info.Until.Format(time.RFC3339)
+				if wait := time.Until(info.Until); wait > 0 {
+					w.Header().Set("Retry-After", strconv.Itoa(int(wait.Round(time.Second).Seconds())))
+				}
+			}
+			if info.Message != "" {
+				resp["message"] = info.Message
+			}
+			api.WriteJSON(w, http.StatusServiceUnavailable, resp)
+			return
+		}
+		next(w, r)
+	})
+}
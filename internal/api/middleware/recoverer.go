@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"go-task-manager-final_project/internal/api"
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// Recoverer - middleware, перехватывающее панику в обработчике, чтобы ошибка одного запроса
+// не завершала весь сервер. Паника записывается в лог вместе со стеком вызовов,
+// а клиент получает 500 (Internal Server Error) с общим сообщением без подробностей.
+// Паника http.ErrAbortHandler (намеренное прерывание ответа) передаётся дальше без изменений.
+// Параметр:
+// next - обработчик HTTP-запроса.
+// Возвращает:
+// обработчик с восстановлением после паники.
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			log.Printf("panic while serving %s %s: %v\n%s", r.Method, r.URL.Path, rec, debug.Stack())
+			api.WriteJSON(w, http.StatusInternalServerError, map[string]string{
+				"error": "internal server error",
+			})
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
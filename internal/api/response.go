@@ -37,3 +37,32 @@ func WriteJSON(w http.ResponseWriter, status int, data interface{}) error {
 
 	return nil
 }
+
+// WriteRawJSON записывает в ответ HTTP уже закодированный JSON без изменений
+// (например, сохранённый ранее ответ на повторный запрос).
+// Параметры:
+// w - объект http.ResponseWriter для отправки ответа клиенту;
+// status - HTTP-статус-код ответа;
+// body - тело ответа в формате JSON.
+// Возвращает:
+// ошибку, если запись ответа не удалась.
+func WriteRawJSON(w http.ResponseWriter, status int, body []byte) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err := w.Write(body)
+	return err
+}
+
+// WriteValidationError отправляет ответ 422 (Unprocessable Entity) для семантически некорректных данных:
+// запрос синтаксически верен (например, валидный JSON), но не проходит проверку (пустой заголовок, неверная дата).
+// Для синтаксических ошибок (некорректный JSON) следует использовать 400 (Bad Request).
+// Параметры:
+// w - объект http.ResponseWriter для отправки ответа клиенту;
+// message - описание ошибки валидации.
+// Возвращает:
+// ошибку, если запись ответа не удалась.
+func WriteValidationError(w http.ResponseWriter, message string) error {
+	return WriteJSON(w, http.StatusUnprocessableEntity, map[string]string{
+		"error": message,
+	})
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBackupKeep - количество хранимых резервных копий БД по умолчанию.
+	DefaultBackupKeep = 5
+
+	// backupSuffix - часть имени резервной копии между именем файла БД и отметкой времени.
+	backupSuffix = ".bak."
+	// backupTimeFormat - формат отметки времени в имени резервной копии (лексикографический порядок совпадает с хронологическим).
+	backupTimeFormat = "20060102T150405"
+)
+
+// BackupFile копирует файл БД в <файл>.bak.<отметка времени> и удаляет самые старые копии сверх keep.
+// Вызывается при запуске до открытия БД (и до обновления схемы в Init), поэтому файл копируется целиком,
+// пока с ним никто не работает. Для БД в памяти (":memory:") и ещё не созданного файла копия не делается.
+// Параметры:
+// dbFile - путь к файлу БД (пустой - файл по умолчанию, как в Init);
+// keep - количество хранимых копий (не меньше 1);
+// now - момент создания копии (для отметки времени в имени).
+// Возвращает:
+// путь к созданной копии (пустой, если копия не делалась) и ошибку (если возникла).
+func BackupFile(dbFile string, keep int, now time.Time) (string, error) {
+	if keep < 1 {
+		return "", fmt.Errorf("backup count must be at least 1: got %d", keep)
+	}
+	if dbFile == "" {
+		dbFile = defaultDBFile
+	}
+	if dbFile == ":memory:" || strings.Contains(dbFile, "mode=memory") {
+		return "", nil
+	}
+
+	src, err := os.Open(dbFile)
+	if os.IsNotExist(err) {
+		// БД ещё не создана - копировать нечего
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to open database file: %w", err)
+	}
+	defer src.Close()
+
+	// Копию пишем во временный файл и переименовываем после записи, чтобы не оставить неполную копию
+	backup := dbFile + backupSuffix + now.Format(backupTimeFormat)
+	tmp := backup + ".tmp"
+	dst, err := os.Create(tmp)
+	if err != nil {
+		return "", fmt.Errorf("failed to create backup file: %w", err)
+	}
+	if _, err = io.Copy(dst, src); err == nil {
+		err = dst.Sync()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("failed to write backup file: %w", err)
+	}
+	if err = os.Rename(tmp, backup); err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("failed to finalize backup file: %w", err)
+	}
+
+	if err = pruneBackups(dbFile, keep); err != nil {
+		return backup, err
+	}
+	return backup, nil
+}
+
+// pruneBackups удаляет самые старые резервные копии файла БД, оставляя keep последних.
+func pruneBackups(dbFile string, keep int) error {
+	backups, err := filepath.Glob(dbFile + backupSuffix + "*")
+	if err != nil {
+		return fmt.Errorf("failed to list backup files: %w", err)
+	}
+
+	// Незавершённые копии (.tmp) не учитываем
+	complete := backups[:0]
+	for _, path := range backups {
+		if !strings.HasSuffix(path, ".tmp") {
+			complete = append(complete, path)
+		}
+	}
+	sort.Strings(complete)
+
+	for len(complete) > keep {
+		if err := os.Remove(complete[0]); err != nil {
+			return fmt.Errorf("failed to remove old backup %s: %w", complete[0], err)
+		}
+		complete = complete[1:]
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	querySelectCompletedIDs = `
+		SELECT id
+		FROM scheduler
+		WHERE archived = 0 AND status = 'done'
+		ORDER BY id ASC
+	`
+)
+
+// ErrCompletedChanged возвращается ClearCompleted, если набор выполненных задач изменился
+// с момента предварительного просмотра.
+var ErrCompletedChanged = errors.New("set of completed tasks has changed")
+
+// CompletedTaskIDs возвращает ID выполненных неархивных задач - отмеченных выполненными (StatusDone).
+// Просроченные задачи в состоянии StatusPending выполненными не считаются.
+// Параметры:
+// db - соединение с базой данных.
+// Возвращает:
+// идентификаторы задач по возрастанию и ошибку (если возникла).
+func CompletedTaskIDs(db *sql.DB) ([]int64, error) {
+	return selectCompletedIDs(db)
+}
+
+// ClearCompleted удаляет выполненные задачи (см. CompletedTaskIDs) в одной транзакции.
+// Удаление выполняется, только если текущий набор таких задач совпадает с ids,
+// полученными при предварительном просмотре; иначе возвращается ErrCompletedChanged и ничего не удаляется.
+// Параметры:
+// db - соединение с базой данных;
+// ids - ожидаемые идентификаторы удаляемых задач.
+// Возвращает:
+// количество удалённых задач и ошибку (если возникла).
+func ClearCompleted(db *sql.DB, ids []int64) (int64, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат не действует после успешного Commit
+	defer tx.Rollback()
+
+	current, err := selectCompletedIDs(tx)
+	if err != nil {
+		return 0, err
+	}
+	if !slices.Equal(current, ids) {
+		return 0, ErrCompletedChanged
+	}
+
+	var count int64
+	for _, id := range ids {
+		res, err := tx.Exec(queryDeleteTask, id)
+		if err != nil {
+			return 0, fmt.Errorf("failed to execute delete query: %w", err)
+		}
+		deleted, err := res.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to retrieve rows affected after delete: %w", err)
+		}
+		count += deleted
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return count, nil
+}
+
+// queryer - общий интерфейс *sql.DB и *sql.Tx для выборки строк.
+type queryer interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
+// selectCompletedIDs выбирает ID выполненных задач через соединение или транзакцию.
+func selectCompletedIDs(q queryer) ([]int64, error) {
+	rows, err := q.Query(querySelectCompletedIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select completed tasks: %w", err)
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan task ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate completed tasks: %w", err)
+	}
+	return ids, nil
+}
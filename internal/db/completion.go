@@ -0,0 +1,177 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Структура Completion представляет отметку о выполнении задачи.
+// ScheduledDate - дата, на которую задача была запланирована (YYYYMMDD);
+// CompletedAt - момент выполнения.
+type Completion struct {
+	TaskID        string
+	ScheduledDate string
+	CompletedAt   time.Time
+}
+
+// CompletedTask - отметка о выполнении вместе с заголовком задачи (для сводок).
+// Title пустой, если задача удалена явно (выполненные разовые задачи сохраняются в состоянии StatusDone).
+type CompletedTask struct {
+	TaskID        string    `json:"task_id"`
+	Title         string    `json:"title"`
+	ScheduledDate string    `json:"scheduled_date"`
+	CompletedAt   time.Time `json:"completed_at"`
+}
+
+const (
+	queryInsertCompletion = `
+		INSERT INTO completions
+		(task_id, scheduled_date, completed_at)
+		VALUES (?, ?, ?)
+	`
+	querySelectCompletions = `
+		SELECT task_id, scheduled_date, completed_at
+		FROM completions
+		WHERE task_id = ?
+		ORDER BY completed_at ASC, id ASC
+	`
+	querySelectCompletedBetween = `
+		SELECT c.task_id, COALESCE(s.title, ''), c.scheduled_date, c.completed_at
+		FROM completions c
+		LEFT JOIN scheduler s ON s.id = c.task_id
+		WHERE c.completed_at >= ? AND c.completed_at < ?
+		ORDER BY c.completed_at ASC, c.id ASC
+	`
+	querySelectFirstScheduledDates = `
+		SELECT task_id, MIN(scheduled_date)
+		FROM completions
+		GROUP BY task_id
+	`
+)
+
+// AddCompletion сохраняет отметку о выполнении задачи.
+// Параметры:
+// db - соединение с базой данных;
+// id - идентификатор задачи;
+// scheduledDate - дата, на которую была запланирована задача;
+// completedAt - момент выполнения.
+// Возвращает ошибку, если операция не удалась.
+func AddCompletion(db *sql.DB, id string, scheduledDate string, completedAt time.Time) error {
+	// Проверяем, что ID не пустой
+	if id == "" {
+		return errors.New("task ID must not be empty")
+	}
+
+	if _, err := db.Exec(queryInsertCompletion, id, scheduledDate, completedAt.Unix()); err != nil {
+		return fmt.Errorf("failed to execute completion insert query: %w", err)
+	}
+	return nil
+}
+
+// GetCompletions получает отметки о выполнении задачи в хронологическом порядке.
+// Параметры:
+// db - соединение с базой данных;
+// id - идентификатор задачи.
+// Возвращает:
+// слайс отметок о выполнении и ошибку (если возникла).
+func GetCompletions(db *sql.DB, id string) ([]Completion, error) {
+	// Проверяем, что ID не пустой
+	if id == "" {
+		return nil, errors.New("task ID must not be empty")
+	}
+
+	rows, err := db.Query(querySelectCompletions, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select completions: %w", err)
+	}
+	// Гарантируем закрытие курсора после завершения работы
+	defer rows.Close()
+
+	var completions []Completion
+	for rows.Next() {
+		var (
+			c           Completion
+			completedAt int64
+		)
+		if err := rows.Scan(&c.TaskID, &c.ScheduledDate, &completedAt); err != nil {
+			return nil, err
+		}
+		c.CompletedAt = time.Unix(completedAt, 0)
+		completions = append(completions, c)
+	}
+
+	// Проверяем, не было ли ошибок при итерации по строкам
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return completions, nil
+}
+
+// GetCompletedBetween получает выполнения задач в интервале [from, to).
+// Параметры:
+// db - соединение с базой данных;
+// from, to - границы интервала (to не включается).
+// Возвращает:
+// слайс выполненных задач в хронологическом порядке и ошибку (если возникла).
+func GetCompletedBetween(db *sql.DB, from, to time.Time) ([]*CompletedTask, error) {
+	rows, err := db.Query(querySelectCompletedBetween, from.Unix(), to.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to select completions: %w", err)
+	}
+	// Гарантируем закрытие курсора после завершения работы
+	defer rows.Close()
+
+	completed := []*CompletedTask{}
+	for rows.Next() {
+		var (
+			c           CompletedTask
+			completedAt int64
+		)
+		if err := rows.Scan(&c.TaskID, &c.Title, &c.ScheduledDate, &completedAt); err != nil {
+			return nil, err
+		}
+		c.CompletedAt = time.Unix(completedAt, 0)
+		completed = append(completed, &c)
+	}
+
+	// Проверяем, не было ли ошибок при итерации по строкам
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return completed, nil
+}
+
+// GetFirstScheduledDates возвращает для каждой задачи с отметками о выполнении самую раннюю
+// запланированную дату выполнения - начало серии повторений задачи.
+// Параметры:
+// db - соединение с базой данных.
+// Возвращает:
+// карту "ID задачи - дата в формате YYYYMMDD" и ошибку (если возникла).
+func GetFirstScheduledDates(db *sql.DB) (map[string]string, error) {
+	rows, err := db.Query(querySelectFirstScheduledDates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select first scheduled dates: %w", err)
+	}
+	// Гарантируем закрытие курсора после завершения работы
+	defer rows.Close()
+
+	dates := map[string]string{}
+	for rows.Next() {
+		var id, date string
+		if err := rows.Scan(&id, &date); err != nil {
+			return nil, err
+		}
+		dates[id] = date
+	}
+
+	// Проверяем, не было ли ошибок при итерации по строкам
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return dates, nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -13,9 +15,43 @@ import (
 const (
 	// Константа defaultDBFile задаёт имя файла БД по умолчанию.
 	defaultDBFile = "scheduler.db"
+
+	// busyTimeoutParam - параметр драйвера SQLite для всех подключений: занятая БД ожидается до 5 секунд,
+	// а не сразу даёт SQLITE_BUSY. Без ожидания COMMIT может завершиться с SQLITE_BUSY, и подключение
+	// вернётся в пул с незавершённой транзакцией (следующий BEGIN на нём получит ошибку).
+	busyTimeoutParam = "_pragma=busy_timeout(5000)"
+
+	// txImmediateParam - параметр драйвера SQLite для режима TODO_TX_IMMEDIATE:
+	// транзакции начинаются с BEGIN IMMEDIATE.
+	txImmediateParam = "_txlock=immediate"
 )
 
-// Константы содержат SQL-скрипты для создания таблицы scheduler и индекса по полю date, если они ещё не существуют.
+// txImmediate включает захват блокировки записи в начале транзакции (BEGIN IMMEDIATE).
+// По умолчанию транзакции отложенные (BEGIN DEFERRED): блокировка берётся при первой записи,
+// и транзакция, уже прочитавшая данные, может получить SQLITE_BUSY посреди работы.
+var txImmediate atomic.Bool
+
+// SetTxImmediate включает или выключает режим BEGIN IMMEDIATE для транзакций.
+// Действует на подключения, открытые через Init после вызова.
+func SetTxImmediate(enabled bool) {
+	txImmediate.Store(enabled)
+}
+
+// dataSourceName формирует строку подключения к файлу БД: ожидание занятой БД задаётся всегда,
+// а BEGIN IMMEDIATE - только в режиме SetTxImmediate.
+func dataSourceName(dbFile string) string {
+	params := busyTimeoutParam
+	if txImmediate.Load() {
+		params = txImmediateParam + "&" + params
+	}
+	if strings.Contains(dbFile, "?") {
+		return dbFile + "&" + params
+	}
+	return dbFile + "?" + params
+}
+
+// Константы содержат SQL-скрипты для создания таблицы scheduler (в первой версии схемы) и индекса по полю date,
+// если они ещё не существуют. Колонки, появившиеся позже, добавляются миграциями (см. migrations).
 const (
 	createTableSQL = `CREATE TABLE IF NOT EXISTS scheduler (
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -27,6 +63,35 @@ const (
 	createIndexSQL = `CREATE INDEX IF NOT EXISTS idx_scheduler_date ON scheduler (date);`
 )
 
+// Служебные таблицы создаются миграцией (IF NOT EXISTS), чтобы они появлялись и в ранее созданной БД.
+const (
+	createIdempotencySQL = `CREATE TABLE IF NOT EXISTS idempotency_keys (
+		key VARCHAR(255) PRIMARY KEY,
+		task_id INTEGER NOT NULL,
+		created_at INTEGER NOT NULL
+	);`
+	createCompletionsSQL = `CREATE TABLE IF NOT EXISTS completions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id INTEGER NOT NULL,
+		scheduled_date CHAR(8) NOT NULL,
+		completed_at INTEGER NOT NULL
+	);`
+	createCompletionsIndexSQL = `CREATE INDEX IF NOT EXISTS idx_completions_task ON completions (task_id);`
+)
+
+// supportingIndexes - индексы для фильтров API. Индекс создаётся, только если в таблице есть нужная колонка,
+// поэтому список можно дополнять заранее: индекс появится, когда колонка будет добавлена в схему.
+var supportingIndexes = []struct {
+	name   string
+	table  string
+	column string
+}{
+	{"idx_scheduler_repeat", "scheduler", "repeat"},
+	{"idx_scheduler_priority", "scheduler", "priority"},
+	{"idx_scheduler_created_at", "scheduler", "created_at"},
+	{"idx_completions_completed_at", "completions", "completed_at"},
+}
+
 // Функция Init инициализирует подключение к базе данных SQLite.
 // Параметры:
 // dbFile - путь к файлу БД (может быть пустым).
@@ -38,7 +103,7 @@ const (
 //  2. Проверяет существование файла БД.
 //  3. Открывает соединение с БД и настраивает параметры подключения.
 //  4. Проверяет доступность БД (ping).
-//  5. Если БД не существовала - создаёт схему (таблицу и индекс).
+//  5. Применяет миграции схемы (см. migrations): в новой БД создаётся вся схема, в существующей - недостающие изменения.
 func Init(dbFile string) (*sql.DB, error) {
 	// Определяем путь к БД: приоритет - переданный аргумент, затем дефолт
 	if dbFile == "" {
@@ -57,7 +122,8 @@ func Init(dbFile string) (*sql.DB, error) {
 	}
 
 	// Открываем соединение с БД
-	db, err := sql.Open("sqlite", dbFile)
+	// (при TODO_TX_IMMEDIATE транзакции захватывают блокировку записи сразу, см. SetTxImmediate)
+	db, err := sql.Open("sqlite", dataSourceName(dbFile))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -76,27 +142,78 @@ func Init(dbFile string) (*sql.DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	// Если БД не существовала - создаём схему (таблицу и индекс)
-	if install {
-		// Выполняем SQL-скрипт создания схемы
-		if _, err = db.Exec(createTableSQL); err != nil {
-			// Закрываем соединение при ошибке создания схемы
-			db.Close()
-			// Отдельная ошибка для CREATE TABLE
-			return nil, fmt.Errorf("failed to create table: %w", err)
-		}
-		// Выполняем SQL-скрипт создания индекса по полю date
-		if _, err = db.Exec(createIndexSQL); err != nil {
-			// Закрываем соединение при ошибке создания индекса
-			db.Close()
-			// Отдельная ошибка для CREATE INDEX
-			return nil, fmt.Errorf("failed to create index: %w", err)
-		}
-		log.Println("База данных инициализирована: таблица и индекс созданы")
-	} else {
+	// Применяем миграции схемы до последней версии (в новой БД - все)
+	applied, err := migrate(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	switch {
+	case install:
+		log.Printf("База данных инициализирована: схема версии %d создана", latestSchemaVersion())
+	case applied > 0:
+		log.Printf("Схема базы данных обновлена до версии %d (применено миграций: %d)", latestSchemaVersion(), applied)
+	default:
 		log.Println("База данных уже существует, схема проверена")
 	}
 
+	// Создаём индексы для фильтров по существующим колонкам
+	if err = ensureIndexes(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
 	// Возвращаем готовое соединение с БД
 	return db, nil
 }
+
+// tableColumns возвращает множество имён колонок таблицы.
+// Параметры:
+// q - соединение с базой данных или транзакция;
+// table - имя таблицы.
+// Возвращает множество колонок и ошибку, если не удалось прочитать схему.
+func tableColumns(q queryer, table string) (map[string]bool, error) {
+	rows, err := q.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read table info: %w", err)
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan table info: %w", err)
+		}
+		columns[name] = true
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read table info: %w", err)
+	}
+	return columns, nil
+}
+
+// ensureIndexes создаёт индексы из supportingIndexes для колонок, которые есть в схеме.
+// Параметры:
+// db - соединение с базой данных.
+// Возвращает ошибку, если не удалось прочитать схему или создать индекс.
+func ensureIndexes(db *sql.DB) error {
+	columns := make(map[string]map[string]bool)
+	for _, index := range supportingIndexes {
+		if columns[index.table] == nil {
+			existing, err := tableColumns(db, index.table)
+			if err != nil {
+				return err
+			}
+			columns[index.table] = existing
+		}
+		if !columns[index.table][index.column] {
+			continue
+		}
+		stmt := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)", index.name, index.table, index.column)
+		if _, err := db.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to create index %s: %w", index.name, err)
+		}
+	}
+	return nil
+}
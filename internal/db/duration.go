@@ -0,0 +1,26 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+const querySumDurationDueOn = `
+	SELECT COALESCE(SUM(duration_minutes), 0), COUNT(*)
+	FROM scheduler
+	WHERE archived = 0 AND status = 'pending' AND date = ?
+`
+
+// SumDurationDueOn суммирует оценки длительности активных (не архивных) задач на указанную дату.
+// Параметры:
+// db - соединение с базой данных;
+// date - дата в формате YYYYMMDD.
+// Возвращает:
+// сумму минут, количество задач на эту дату и ошибку (если возникла).
+func SumDurationDueOn(db *sql.DB, date string) (int64, int64, error) {
+	var total, count int64
+	if err := db.QueryRow(querySumDurationDueOn, date).Scan(&total, &count); err != nil {
+		return 0, 0, fmt.Errorf("failed to sum task durations: %w", err)
+	}
+	return total, count, nil
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// dateLayout - формат хранения дат задач (YYYYMMDD), совпадает с scheduler.DateFormat.
+const dateLayout = "20060102"
+
+const queryFixEmptyDates = `
+	UPDATE scheduler
+	SET date = ?
+	WHERE date = '' OR date IS NULL
+`
+
+// FixEmptyDates исправляет задачи с пустой датой (значение колонки по умолчанию, ручные правки),
+// устанавливая им сегодняшнюю дату. Вызывается при запуске сервера.
+// Параметры:
+// db - соединение с базой данных.
+// Возвращает:
+// количество исправленных задач и ошибку (если возникла).
+func FixEmptyDates(db *sql.DB) (int64, error) {
+	res, err := db.Exec(queryFixEmptyDates, time.Now().Format(dateLayout))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fix empty dates: %w", err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve rows affected count: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+const querySelectAllTasks = `
+	SELECT ` + taskColumns + `
+	FROM scheduler
+	ORDER BY id ASC
+`
+
+// EachTask последовательно передаёт в fn все задачи (включая архивные) в порядке возрастания ID.
+// Задачи читаются из курсора по одной и не накапливаются в памяти, поэтому подходит для выгрузки больших объёмов.
+// Параметры:
+// db - соединение с базой данных;
+// fn - функция, вызываемая для каждой задачи; ошибка fn прерывает обход.
+// Возвращает ошибку чтения из БД или ошибку fn.
+func EachTask(db *sql.DB, fn func(task *Task) error) error {
+	rows, err := db.Query(querySelectAllTasks)
+	if err != nil {
+		return fmt.Errorf("failed to query tasks: %w", err)
+	}
+	// Гарантируем закрытие курсора после завершения работы
+	defer rows.Close()
+
+	for rows.Next() {
+		var task Task
+		if err := scanTask(rows, &task); err != nil {
+			return fmt.Errorf("failed to scan task data: %w", err)
+		}
+		if err := fn(&task); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to read tasks: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	querySelectOverdue = `
+		SELECT ` + taskColumns + `
+		FROM scheduler
+		WHERE archived = 0 AND status = 'pending' AND date < ?
+		ORDER BY date ASC, id ASC
+		LIMIT ?
+	`
+	querySelectDueOn = `
+		SELECT ` + taskColumns + `
+		FROM scheduler
+		WHERE archived = 0 AND status = 'pending' AND date = ?
+		ORDER BY id ASC
+		LIMIT ?
+	`
+	querySelectUpcoming = `
+		SELECT ` + taskColumns + `
+		FROM scheduler
+		WHERE archived = 0 AND status = 'pending' AND date > ?
+		ORDER BY date ASC, id ASC
+		LIMIT ?
+	`
+	queryRescheduleOverdue = `
+		UPDATE scheduler
+		SET date = ?, updated_at = ?
+		WHERE archived = 0 AND status = 'pending' AND date < ?
+	`
+	queryRescheduleOverdueOnce = queryRescheduleOverdue + ` AND (repeat IS NULL OR repeat = '')`
+)
+
+// GroupedTasks содержит задачи, разложенные по группам относительно текущей даты.
+type GroupedTasks struct {
+	Overdue  []*Task `json:"overdue"`
+	Today    []*Task `json:"today"`
+	Upcoming []*Task `json:"upcoming"`
+}
+
+// GetGroupedTasks получает задачи, сгруппированные на просроченные, сегодняшние и предстоящие.
+// Параметры:
+// db - соединение с базой данных;
+// today - текущая дата в формате YYYYMMDD;
+// limit - максимальное количество задач в каждой группе.
+// Возвращает:
+// указатель на структуру GroupedTasks (группы отсортированы по дате) и ошибку (если возникла).
+func GetGroupedTasks(db *sql.DB, today string, limit int) (*GroupedTasks, error) {
+	// Проверяем, что limit положительный
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than 0")
+	}
+
+	overdue, err := queryTasks(db, querySelectOverdue, today, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select overdue tasks: %w", err)
+	}
+	dueToday, err := queryTasks(db, querySelectDueOn, today, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select today tasks: %w", err)
+	}
+	upcoming, err := queryTasks(db, querySelectUpcoming, today, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select upcoming tasks: %w", err)
+	}
+
+	// Пустые группы возвращаем как пустые массивы, а не null
+	groups := &GroupedTasks{
+		Overdue:  []*Task{},
+		Today:    []*Task{},
+		Upcoming: []*Task{},
+	}
+	groups.Overdue = append(groups.Overdue, overdue...)
+	groups.Today = append(groups.Today, dueToday...)
+	groups.Upcoming = append(groups.Upcoming, upcoming...)
+
+	return groups, nil
+}
+
+const querySelectDueBy = `
+	SELECT ` + taskColumns + `
+	FROM scheduler
+	WHERE archived = 0 AND status = 'pending' AND date != '' AND date <= ?
+	ORDER BY date ASC, id ASC
+	LIMIT ?
+`
+
+// GetTasksDueBy получает задачи с датой не позже указанной.
+// Параметры:
+// db - соединение с базой данных;
+// date - граничная дата в формате YYYYMMDD (включительно);
+// limit - максимальное количество возвращаемых задач.
+// Возвращает:
+// слайс указателей на структуры Task (по возрастанию даты) и ошибку (если возникла).
+func GetTasksDueBy(db *sql.DB, date string, limit int) ([]*Task, error) {
+	// Проверяем, что limit положительный
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than 0")
+	}
+	return queryTasks(db, querySelectDueBy, date, limit)
+}
+
+// RescheduleOverdue переносит все просроченные (date < today) неархивные задачи на сегодня одним запросом.
+// Параметры:
+// db - соединение с базой данных;
+// today - текущая дата в формате YYYYMMDD;
+// onceOnly - переносить только задачи без правила повторения.
+// Возвращает:
+// количество перенесённых задач и ошибку (если возникла).
+func RescheduleOverdue(db *sql.DB, today string, onceOnly bool) (int64, error) {
+	query := queryRescheduleOverdue
+	if onceOnly {
+		query = queryRescheduleOverdueOnce
+	}
+
+	res, err := db.Exec(query, today, timestamp(time.Now()), today)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reschedule overdue tasks: %w", conflictError(err))
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve rows affected count: %w", err)
+	}
+	return count, nil
+}
+
+const querySelectBetween = `
+	SELECT ` + taskColumns + `
+	FROM scheduler
+	WHERE archived = 0 AND status = 'pending' AND date >= ? AND date <= ?
+	ORDER BY date ASC, id ASC
+	LIMIT ?
+`
+
+// GetTasksBetween получает задачи с датой в диапазоне [from, to] включительно.
+// Параметры:
+// db - соединение с базой данных;
+// from, to - границы диапазона в формате YYYYMMDD;
+// limit - максимальное количество возвращаемых задач.
+// Возвращает:
+// слайс указателей на структуры Task (по возрастанию даты) и ошибку (если возникла).
+func GetTasksBetween(db *sql.DB, from, to string, limit int) ([]*Task, error) {
+	// Проверяем, что limit положительный
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than 0")
+	}
+	return queryTasks(db, querySelectBetween, from, to, limit)
+}
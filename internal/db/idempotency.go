@@ -0,0 +1,190 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	queryDeleteExpiredKeys = `
+		DELETE FROM idempotency_keys
+		WHERE created_at < ?
+	`
+	querySelectKey = `
+		SELECT task_id, request_hash, response
+		FROM idempotency_keys
+		WHERE key = ?
+	`
+	queryInsertKey = `
+		INSERT INTO idempotency_keys
+		(key, task_id, created_at, request_hash, response)
+		VALUES (?, ?, ?, ?, ?)
+	`
+)
+
+// ErrIdempotencyKeyReused - ключ идемпотентности уже использован для запроса с другим телом.
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different request")
+
+// IdempotentResult - результат AddTaskIdempotent.
+type IdempotentResult struct {
+	TaskID   int64  // ID задачи (новой или созданной ранее)
+	Created  bool   // true, если задача создана этим запросом
+	Response []byte // ответ на первый запрос с этим ключом (пустой для ключей, сохранённых до появления колонки response)
+}
+
+// rowQueryer - общий интерфейс *sql.DB и *sql.Tx для выборки одной строки.
+type rowQueryer interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// AddTaskIdempotent добавляет задачу с учётом ключа идемпотентности.
+// Если задача с таким ключом уже создавалась (и ключ не устарел), новая задача не создаётся, а возвращается
+// сохранённый ответ на первый запрос; это верно и для параллельных запросов с одним ключом.
+// Повтор с тем же ключом, но другим телом запроса, возвращает ErrIdempotencyKeyReused,
+// а повтор для уже удалённой задачи - ErrTaskNotFound.
+// Параметры:
+// db - соединение с базой данных;
+// task - указатель на структуру Task с данными задачи;
+// key - ключ идемпотентности (из заголовка Idempotency-Key);
+// requestHash - хеш тела запроса;
+// ttl - время жизни ключа;
+// respond - формирует тело ответа для созданной задачи; ответ сохраняется вместе с ключом.
+// Возвращает:
+// результат (ID задачи, признак создания и ответ) и ошибку (если возникла).
+func AddTaskIdempotent(db *sql.DB, task *Task, key, requestHash string, ttl time.Duration, respond func(created *Task) ([]byte, error)) (IdempotentResult, error) {
+	// Проверяем входные данные
+	if task == nil {
+		return IdempotentResult{}, errors.New("task cannot be nil")
+	}
+	if key == "" {
+		return IdempotentResult{}, errors.New("idempotency key must not be empty")
+	}
+
+	// Поиск ключа и создание задачи выполняются в одной транзакции
+	tx, err := db.Begin()
+	if err != nil {
+		return IdempotentResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат не действует после успешного Commit
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	// Удаляем устаревшие ключи, чтобы они не препятствовали повторному использованию
+	if _, err = tx.Exec(queryDeleteExpiredKeys, now.Add(-ttl).Unix()); err != nil {
+		return IdempotentResult{}, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	// Если ключ уже обработан - возвращаем сохранённый ответ
+	result, found, err := selectKey(tx, key, requestHash)
+	if err != nil {
+		return IdempotentResult{}, err
+	}
+	if found {
+		return result, tx.Commit()
+	}
+
+	// Создаём задачу
+	created := *task
+	created.CreatedAt = timestamp(now)
+	created.UpdatedAt = created.CreatedAt
+	created.Status = StatusPending
+	res, err := tx.Exec(queryInsertTask, created.Date, created.Title, created.Comment, created.Repeat, created.Color, created.DurationMinutes, created.CreatedAt, created.UpdatedAt)
+	if err != nil {
+		return concurrentKeyTask(db, tx, key, requestHash, fmt.Errorf("failed to execute insert query: %w", conflictError(err)))
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return IdempotentResult{}, fmt.Errorf("failed to retrieve last insert ID: %w", err)
+	}
+	created.ID = strconv.FormatInt(id, 10)
+
+	response, err := respond(&created)
+	if err != nil {
+		return IdempotentResult{}, fmt.Errorf("failed to build response: %w", err)
+	}
+
+	// Запоминаем ключ вместе с ID созданной задачи и ответом
+	if _, err = tx.Exec(queryInsertKey, key, id, now.Unix(), requestHash, string(response)); err != nil {
+		return concurrentKeyTask(db, tx, key, requestHash, fmt.Errorf("failed to save idempotency key: %w", conflictError(err)))
+	}
+
+	if err = tx.Commit(); err != nil {
+		return IdempotentResult{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return IdempotentResult{TaskID: id, Created: true, Response: response}, nil
+}
+
+// selectKey ищет обработанный ключ идемпотентности через соединение или транзакцию.
+// Параметры:
+// q - соединение или транзакция;
+// key - ключ идемпотентности;
+// requestHash - хеш тела текущего запроса.
+// Возвращает:
+// сохранённый результат, признак того, что ключ найден, и ошибку: ErrIdempotencyKeyReused, если тело запроса
+// отличается от первого, и ErrTaskNotFound, если созданная по ключу задача удалена.
+func selectKey(q rowQueryer, key, requestHash string) (IdempotentResult, bool, error) {
+	var (
+		result     IdempotentResult
+		storedHash string
+		response   string
+	)
+	err := q.QueryRow(querySelectKey, key).Scan(&result.TaskID, &storedHash, &response)
+	if errors.Is(err, sql.ErrNoRows) {
+		return IdempotentResult{}, false, nil
+	}
+	if err != nil {
+		return IdempotentResult{}, false, fmt.Errorf("failed to select idempotency key: %w", err)
+	}
+
+	// Для ключей, сохранённых до появления колонки request_hash, тело запроса не сравнивается
+	if storedHash != "" && storedHash != requestHash {
+		return IdempotentResult{}, true, ErrIdempotencyKeyReused
+	}
+
+	var exists bool
+	if err := q.QueryRow(queryTaskExists, result.TaskID).Scan(&exists); err != nil {
+		return IdempotentResult{}, true, fmt.Errorf("failed to check task: %w", err)
+	}
+	if !exists {
+		return IdempotentResult{}, true, fmt.Errorf("task with ID %d: %w", result.TaskID, ErrTaskNotFound)
+	}
+
+	result.Response = []byte(response)
+	return result, true, nil
+}
+
+// concurrentKeyTask обрабатывает ошибку вставки в AddTaskIdempotent.
+// Если это нарушение ограничения (ErrConflict), параллельный запрос с тем же ключом мог успеть
+// создать задачу первым: транзакция откатывается, и ключ читается повторно, чтобы оба запроса
+// вернули один и тот же ответ.
+// Параметры:
+// db - соединение с базой данных;
+// tx - незавершённая транзакция AddTaskIdempotent;
+// key - ключ идемпотентности;
+// requestHash - хеш тела запроса;
+// cause - исходная ошибка вставки.
+// Возвращает:
+// результат первого запроса, если ключ найден; иначе исходную ошибку.
+func concurrentKeyTask(db *sql.DB, tx *sql.Tx, key, requestHash string, cause error) (IdempotentResult, error) {
+	if !errors.Is(cause, ErrConflict) {
+		return IdempotentResult{}, cause
+	}
+	// Откатываем свою вставку до повторного чтения: иначе транзакция продолжит видеть старый снимок
+	if err := tx.Rollback(); err != nil {
+		return IdempotentResult{}, fmt.Errorf("failed to rollback transaction: %w", err)
+	}
+
+	result, found, err := selectKey(db, key, requestHash)
+	if err != nil {
+		return IdempotentResult{}, err
+	}
+	if !found {
+		// Ключ не сохранён - конфликт вызван не параллельным запросом
+		return IdempotentResult{}, cause
+	}
+	return result, nil
+}
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrDuplicateID - при импорте с сохранением ID задача с таким ID уже есть в базе данных.
+var ErrDuplicateID = errors.New("task ID already exists")
+
+const (
+	queryImportTask = `
+		INSERT INTO scheduler (date, title, comment, repeat, color, duration_minutes, archived, created_at, updated_at, status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	queryImportTaskWithID = `
+		INSERT INTO scheduler (id, date, title, comment, repeat, color, duration_minutes, archived, created_at, updated_at, status)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	queryTaskExists = `SELECT EXISTS (SELECT 1 FROM scheduler WHERE id = ?)`
+)
+
+// ImportTasks добавляет задачи из импорта в одной транзакции: при ошибке не добавляется ни одна задача.
+// В отличие от AddTasks, сохраняет признак архивной задачи и её состояние (по умолчанию - StatusPending).
+// Параметры:
+// db - соединение с базой данных;
+// tasks - задачи для импорта;
+// keepIDs - сохранить ID из импорта (иначе ID назначаются заново).
+// Возвращает:
+// ID добавленных задач в порядке импорта и ошибку
+// (ErrDuplicateID, если при keepIDs задача с таким ID уже существует).
+func ImportTasks(db *sql.DB, tasks []*Task, keepIDs bool) ([]int64, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат не действует после успешного Commit
+	defer tx.Rollback()
+
+	now := timestamp(time.Now())
+	ids := make([]int64, 0, len(tasks))
+	for _, task := range tasks {
+		if task == nil {
+			return nil, errors.New("task cannot be nil")
+		}
+
+		// Время создания и изменения из выгрузки сохраняется, а для задач без него берётся текущее
+		created, updated := task.CreatedAt, task.UpdatedAt
+		if created == "" {
+			created = now
+		}
+		if updated == "" {
+			updated = created
+		}
+		status := task.Status
+		if status == "" {
+			status = StatusPending
+		}
+		if !ValidStatus(status) {
+			return nil, fmt.Errorf("invalid task status %q", status)
+		}
+
+		var res sql.Result
+		if keepIDs {
+			id, err := strconv.ParseInt(task.ID, 10, 64)
+			if err != nil || id <= 0 {
+				return nil, fmt.Errorf("invalid task ID %q: must be a positive integer", task.ID)
+			}
+			// Проверяем коллизию заранее, чтобы вернуть понятную ошибку с номером ID
+			var exists bool
+			if err := tx.QueryRow(queryTaskExists, id).Scan(&exists); err != nil {
+				return nil, fmt.Errorf("failed to check task ID: %w", err)
+			}
+			if exists {
+				return nil, fmt.Errorf("%w: %d", ErrDuplicateID, id)
+			}
+			res, err = tx.Exec(queryImportTaskWithID, id, task.Date, task.Title, task.Comment, task.Repeat, task.Color, task.DurationMinutes, task.Archived, created, updated, status)
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute insert query: %w", conflictError(err))
+			}
+		} else {
+			res, err = tx.Exec(queryImportTask, task.Date, task.Title, task.Comment, task.Repeat, task.Color, task.DurationMinutes, task.Archived, created, updated, status)
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute insert query: %w", conflictError(err))
+			}
+		}
+
+		id, err := res.LastInsertId()
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve last insert ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return ids, nil
+}
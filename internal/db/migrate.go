@@ -0,0 +1,156 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// createMigrationsSQL - таблица применённых версий схемы: по строке на каждую миграцию.
+const createMigrationsSQL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version INTEGER PRIMARY KEY,
+	applied_at INTEGER NOT NULL
+);`
+
+// migration - шаг изменения схемы БД.
+// Шаги идемпотентны (IF NOT EXISTS, проверка колонок), поэтому их можно применить и к БД,
+// созданной до появления schema_migrations, в которой часть изменений уже есть.
+type migration struct {
+	version     int
+	description string
+	statements  []string      // SQL-скрипты шага
+	table       string        // таблица для columns (по умолчанию scheduler)
+	columns     []addedColumn // колонки, добавляемые в таблицу, если их ещё нет
+}
+
+// addedColumn - колонка, добавляемая в существующую таблицу через ALTER TABLE.
+type addedColumn struct {
+	name       string
+	definition string
+}
+
+// migrations - миграции схемы в порядке версий. Новые изменения схемы добавляются в конец списка
+// со следующим номером версии; уже выпущенные шаги не меняются.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "scheduler table",
+		statements:  []string{createTableSQL, createIndexSQL},
+	},
+	{
+		version:     2,
+		description: "scheduler columns: archived, color, duration_minutes, created_at, updated_at",
+		columns: []addedColumn{
+			{"archived", "INTEGER NOT NULL DEFAULT 0"},
+			{"color", "VARCHAR(16) NOT NULL DEFAULT ''"},
+			{"duration_minutes", "INTEGER NOT NULL DEFAULT 0"},
+			{"created_at", "VARCHAR(32) NOT NULL DEFAULT ''"},
+			{"updated_at", "VARCHAR(32) NOT NULL DEFAULT ''"},
+		},
+	},
+	{
+		version:     3,
+		description: "idempotency keys and completions",
+		statements:  []string{createIdempotencySQL, createCompletionsSQL, createCompletionsIndexSQL},
+	},
+	{
+		version:     4,
+		description: "scheduler column: status",
+		columns: []addedColumn{
+			{"status", "VARCHAR(16) NOT NULL DEFAULT 'pending'"},
+		},
+	},
+	{
+		version:     5,
+		description: "idempotency_keys columns: request_hash, response",
+		table:       "idempotency_keys",
+		columns: []addedColumn{
+			{"request_hash", "VARCHAR(64) NOT NULL DEFAULT ''"},
+			{"response", "TEXT NOT NULL DEFAULT ''"},
+		},
+	},
+}
+
+// latestSchemaVersion возвращает версию схемы после применения всех миграций.
+func latestSchemaVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+// migrate применяет к БД миграции с версией выше текущей в одной транзакции:
+// при ошибке схема остаётся в исходном состоянии.
+// Параметры:
+// db - соединение с базой данных.
+// Возвращает:
+// количество применённых миграций и ошибку (в том числе если версия БД новее известной приложению).
+func migrate(db *sql.DB) (int, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin migration transaction: %w", err)
+	}
+	// Откат не действует после успешного Commit
+	defer tx.Rollback()
+
+	if _, err = tx.Exec(createMigrationsSQL); err != nil {
+		return 0, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	var current int
+	if err = tx.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if latest := latestSchemaVersion(); current > latest {
+		return 0, fmt.Errorf("database schema version %d is newer than supported version %d", current, latest)
+	}
+
+	applied := 0
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err = applyMigration(tx, m); err != nil {
+			return 0, fmt.Errorf("failed to apply migration %d (%s): %w", m.version, m.description, err)
+		}
+		if _, err = tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, m.version, time.Now().Unix()); err != nil {
+			return 0, fmt.Errorf("failed to record migration %d: %w", m.version, err)
+		}
+		applied++
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit migrations: %w", err)
+	}
+	return applied, nil
+}
+
+// applyMigration выполняет скрипты миграции и добавляет отсутствующие колонки таблицы (по умолчанию scheduler).
+func applyMigration(tx *sql.Tx, m migration) error {
+	for _, stmt := range m.statements {
+		if _, err := tx.Exec(stmt); err != nil {
+			return err
+		}
+	}
+	if len(m.columns) == 0 {
+		return nil
+	}
+
+	table := m.table
+	if table == "" {
+		table = "scheduler"
+	}
+	existing, err := tableColumns(tx, table)
+	if err != nil {
+		return err
+	}
+	for _, c := range m.columns {
+		if existing[c.name] {
+			continue
+		}
+		stmt := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, c.name, c.definition)
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to add column %s: %w", c.name, err)
+		}
+		log.Printf("В таблицу %s добавлена колонка %s", table, c.name)
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package db
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+
+	"modernc.org/sqlite"
+)
+
+// Операторы поискового запроса (регистр важен, чтобы слова "and"/"or" в тексте оставались обычными терминами).
+const (
+	searchAnd = "AND"
+	searchOr  = "OR"
+)
+
+// searchLowerFunc - SQL-функция приведения к нижнему регистру с поддержкой Unicode.
+// Встроенная LOWER в SQLite работает только с ASCII, поэтому кириллица сравнивалась бы с учётом регистра.
+const searchLowerFunc = "unicode_lower"
+
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction(searchLowerFunc, 1, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		switch v := args[0].(type) {
+		case string:
+			return strings.ToLower(v), nil
+		case []byte:
+			return strings.ToLower(string(v)), nil
+		default:
+			return v, nil
+		}
+	})
+}
+
+// ErrInvalidSearch - ошибка разбора поискового запроса (например, оператор без термина).
+var ErrInvalidSearch = errors.New("invalid search query")
+
+// searchToken - элемент поискового запроса: термин (слово или фраза в кавычках) либо оператор.
+type searchToken struct {
+	text     string
+	operator bool
+}
+
+// tokenizeSearch разбивает запрос на термины и операторы AND/OR.
+// Фраза в двойных кавычках считается одним термином (незакрытая кавычка действует до конца строки).
+func tokenizeSearch(query string) []searchToken {
+	var tokens []searchToken
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		switch {
+		case runes[i] == ' ' || runes[i] == '\t':
+			i++
+		case runes[i] == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if phrase := string(runes[i+1 : end]); phrase != "" {
+				tokens = append(tokens, searchToken{text: phrase})
+			}
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && runes[end] != ' ' && runes[end] != '\t' && runes[end] != '"' {
+				end++
+			}
+			word := string(runes[i:end])
+			tokens = append(tokens, searchToken{text: word, operator: word == searchAnd || word == searchOr})
+			i = end
+		}
+	}
+	return tokens
+}
+
+// parseSearch разбирает поисковый запрос в дизъюнкцию конъюнкций: результат [][]string
+// означает (t11 AND t12 ...) OR (t21 AND ...) OR ...; AND связывает сильнее, чем OR.
+// Термины, идущие подряд без оператора, объединяются через AND.
+// Если операторов в запросе нет, весь запрос (без внешних кавычек) ищется как одна подстрока.
+// Возвращает ErrInvalidSearch, если оператор стоит в начале, в конце или рядом с другим оператором.
+func parseSearch(query string) ([][]string, error) {
+	query = strings.TrimSpace(query)
+	tokens := tokenizeSearch(query)
+
+	hasOperator := false
+	for _, token := range tokens {
+		if token.operator {
+			hasOperator = true
+			break
+		}
+	}
+	if !hasOperator {
+		phrase := query
+		if len(tokens) == 1 {
+			phrase = tokens[0].text
+		}
+		if phrase == "" {
+			return nil, nil
+		}
+		return [][]string{{phrase}}, nil
+	}
+
+	groups := [][]string{{}}
+	expectTerm := true
+	for _, token := range tokens {
+		if token.operator {
+			if expectTerm {
+				return nil, fmt.Errorf("%w: operator %s without a term", ErrInvalidSearch, token.text)
+			}
+			if token.text == searchOr {
+				groups = append(groups, []string{})
+			}
+			expectTerm = true
+			continue
+		}
+		last := len(groups) - 1
+		groups[last] = append(groups[last], token.text)
+		expectTerm = false
+	}
+	if expectTerm {
+		return nil, fmt.Errorf("%w: query ends with an operator", ErrInvalidSearch)
+	}
+	return groups, nil
+}
+
+// SearchTerms возвращает термины поискового запроса (без операторов) в порядке появления.
+// Параметры:
+// query - поисковый запрос.
+// Возвращает: термины и ErrInvalidSearch при некорректном запросе.
+func SearchTerms(query string) ([]string, error) {
+	groups, err := parseSearch(query)
+	if err != nil {
+		return nil, err
+	}
+	var terms []string
+	for _, group := range groups {
+		terms = append(terms, group...)
+	}
+	return terms, nil
+}
+
+// likePattern экранирует спецсимволы LIKE и оборачивает термин в % для поиска подстроки.
+func likePattern(term string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + replacer.Replace(strings.ToLower(term)) + "%"
+}
+
+// buildSearchCondition превращает разобранный запрос в SQL-условие с плейсхолдерами и список аргументов.
+// Каждый термин ищется без учёта регистра в заголовке задачи, а при searchComments - и в комментарии.
+func buildSearchCondition(groups [][]string, searchComments bool) (string, []any) {
+	orParts := make([]string, 0, len(groups))
+	var args []any
+	for _, group := range groups {
+		andParts := make([]string, 0, len(group))
+		for _, term := range group {
+			pattern := likePattern(term)
+			if !searchComments {
+				andParts = append(andParts, searchLowerFunc+`(title) LIKE ? ESCAPE '\'`)
+				args = append(args, pattern)
+				continue
+			}
+			andParts = append(andParts, "("+searchLowerFunc+`(title) LIKE ? ESCAPE '\' OR `+searchLowerFunc+`(comment) LIKE ? ESCAPE '\')`)
+			args = append(args, pattern, pattern)
+		}
+		orParts = append(orParts, "("+strings.Join(andParts, " AND ")+")")
+	}
+	return strings.Join(orParts, " OR "), args
+}
+
+// SearchFilter - дополнительные условия отбора результатов поиска (SearchTasks, SearchTasksByDate).
+type SearchFilter struct {
+	IncludeArchived bool   // включать ли архивные задачи
+	Status          string // состояние задач (StatusPending или StatusDone; пустая строка - любое)
+	FilterRepeat    bool   // отбирать только задачи с правилом повторения Repeat
+	Repeat          string // правило повторения (точное совпадение; пустая строка - задачи без повторения)
+}
+
+// condition возвращает SQL-условия фильтра (каждое начинается с " AND ") и их аргументы.
+func (f SearchFilter) condition() (string, []any) {
+	var condition string
+	var args []any
+	if !f.IncludeArchived {
+		condition += ` AND archived = 0`
+	}
+	if f.Status != "" {
+		condition += ` AND status = ?`
+		args = append(args, f.Status)
+	}
+	if f.FilterRepeat {
+		condition += ` AND COALESCE(repeat, '') = ?`
+		args = append(args, f.Repeat)
+	}
+	return condition, args
+}
+
+// SearchTasks ищет задачи по тексту в заголовке или комментарии.
+// Поддерживаются фразы в кавычках и операторы AND/OR между терминами (например, `groceries AND urgent`, `home OR work`).
+// Отбор по фильтру и постраничный вывод выполняются в SQL, поэтому совпадения не теряются за пределами страницы.
+// Параметры:
+// db - соединение с базой данных;
+// query - поисковый запрос;
+// searchComments - искать ли в комментариях (иначе только в заголовках);
+// filter - дополнительные условия отбора;
+// limit - максимальное количество задач в результате;
+// offset - количество пропускаемых задач (для постраничного вывода).
+// Возвращает:
+// найденные задачи и ошибку (ErrInvalidSearch при некорректном запросе).
+func SearchTasks(db *sql.DB, query string, searchComments bool, filter SearchFilter, limit, offset int) ([]*Task, error) {
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than 0")
+	}
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+
+	groups, err := parseSearch(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return []*Task{}, nil
+	}
+
+	condition, args := buildSearchCondition(groups, searchComments)
+	filterCondition, filterArgs := filter.condition()
+	stmt := `SELECT ` + taskColumns + ` FROM scheduler WHERE (` + condition + `)` + filterCondition +
+		` ORDER BY date ASC, id ASC LIMIT ? OFFSET ?`
+	args = append(args, filterArgs...)
+	args = append(args, limit, offset)
+
+	tasks, err := queryTasks(db, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	return tasks, nil
+}
+
+// SearchTasksByDate ищет задачи, назначенные на указанную дату.
+// Сравнение, отбор по фильтру и постраничный вывод выполняются в SQL по всей таблице, а не по первым выбранным задачам.
+// Параметры:
+// db - соединение с базой данных;
+// date - дата в формате хранения (YYYYMMDD);
+// filter - дополнительные условия отбора;
+// limit - максимальное количество задач в результате;
+// offset - количество пропускаемых задач (для постраничного вывода).
+// Возвращает:
+// найденные задачи и ошибку.
+func SearchTasksByDate(db *sql.DB, date string, filter SearchFilter, limit, offset int) ([]*Task, error) {
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than 0")
+	}
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+
+	filterCondition, filterArgs := filter.condition()
+	stmt := `SELECT ` + taskColumns + ` FROM scheduler WHERE date = ?` + filterCondition + ` ORDER BY id ASC LIMIT ? OFFSET ?`
+	args := append([]any{date}, filterArgs...)
+	args = append(args, limit, offset)
+
+	tasks, err := queryTasks(db, stmt, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tasks by date: %w", err)
+	}
+	return tasks, nil
+}
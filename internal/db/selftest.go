@@ -0,0 +1,45 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// selfTestTitle - заголовок пробной задачи, создаваемой при самопроверке.
+const selfTestTitle = "__selftest__"
+
+// SelfTest проверяет работоспособность БД перед обслуживанием запросов:
+// создаёт пробную задачу, читает её и удаляет. Так неверные права доступа к файлу БД
+// или повреждённая схема обнаруживаются при запуске, а не на первом запросе пользователя.
+// Параметры:
+// db - соединение с базой данных.
+// Возвращает ошибку с указанием шага, на котором самопроверка не прошла.
+func SelfTest(db *sql.DB) error {
+	probe := &Task{
+		Date:  time.Now().Format(dateLayout),
+		Title: selfTestTitle,
+	}
+
+	id, err := AddTask(db, probe)
+	if err != nil {
+		return fmt.Errorf("self-test: insert probe task: %w", err)
+	}
+	probeID := strconv.FormatInt(id, 10)
+
+	task, err := GetTask(db, probeID)
+	if err == nil && task.Title != selfTestTitle {
+		err = fmt.Errorf("unexpected title %q", task.Title)
+	}
+	if err != nil {
+		// Пытаемся не оставлять пробную задачу в БД
+		DeleteTask(db, probeID)
+		return fmt.Errorf("self-test: read probe task: %w", err)
+	}
+
+	if err = DeleteTask(db, probeID); err != nil {
+		return fmt.Errorf("self-test: delete probe task: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	querySelectTaskDate = `SELECT date FROM scheduler WHERE id = ?`
+	querySetRepeat      = `UPDATE scheduler SET repeat = ?, date = ?, updated_at = ? WHERE id = ?`
+)
+
+// SetRepeat назначает правило повторения нескольким задачам в одной транзакции.
+// Дата каждой задачи пересчитывается функцией reschedule (например, если она уже в прошлом).
+// Если хотя бы одна задача не найдена или не может быть обновлена, ни одна задача не изменяется.
+// Параметры:
+// db - соединение с базой данных;
+// ids - идентификаторы задач;
+// repeat - новое правило повторения;
+// reschedule - функция, возвращающая новую дату задачи по её текущей дате.
+// Возвращает:
+// количество обновлённых задач и ошибку (ErrTaskNotFound в цепочке, если задача не найдена).
+func SetRepeat(db *sql.DB, ids []int64, repeat string, reschedule func(date string) (string, error)) (int64, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат не действует после успешного Commit
+	defer tx.Rollback()
+
+	var count int64
+	for _, id := range ids {
+		var date string
+		err := tx.QueryRow(querySelectTaskDate, id).Scan(&date)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("task with ID %d: %w", id, ErrTaskNotFound)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read task date: %w", err)
+		}
+
+		next, err := reschedule(date)
+		if err != nil {
+			return 0, fmt.Errorf("task with ID %d: %w", id, err)
+		}
+
+		res, err := tx.Exec(querySetRepeat, repeat, next, timestamp(time.Now()), id)
+		if err != nil {
+			return 0, fmt.Errorf("failed to execute repeat update query: %w", conflictError(err))
+		}
+		updated, err := res.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to retrieve rows affected count: %w", err)
+		}
+		count += updated
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Состояния задачи (колонка status).
+const (
+	StatusPending = "pending" // задача активна
+	StatusDone    = "done"    // разовая задача выполнена
+)
+
+const (
+	queryUpdateStatus = `
+		UPDATE scheduler
+		SET status = ?, updated_at = ?
+		WHERE id = ?
+	`
+	querySelectTasksByStatus = `
+		SELECT ` + taskColumns + `
+		FROM scheduler
+		WHERE (archived = 0 OR ?) AND (? = '' OR status = ?)
+		ORDER BY date ASC, id ASC
+		LIMIT ? OFFSET ?
+	`
+)
+
+// ValidStatus сообщает, является ли status допустимым состоянием задачи.
+func ValidStatus(status string) bool {
+	return status == StatusPending || status == StatusDone
+}
+
+// UpdateStatus изменяет состояние задачи.
+// Параметры:
+// db - соединение с базой данных;
+// id - идентификатор задачи;
+// status - новое состояние (StatusPending или StatusDone).
+// Возвращает ошибку, если операция не удалась (ErrTaskNotFound, если задачи нет).
+func UpdateStatus(db *sql.DB, id string, status string) error {
+	// Валидация входных данных
+	if id == "" {
+		return errors.New("task ID must not be empty")
+	}
+	if !ValidStatus(status) {
+		return fmt.Errorf("invalid task status %q", status)
+	}
+
+	res, err := db.Exec(queryUpdateStatus, status, timestamp(time.Now()), id)
+	if err != nil {
+		return fmt.Errorf("failed to execute status update query: %w", err)
+	}
+
+	// Получаем количество затронутых строк (должно быть 1 для успешного обновления)
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve rows affected count: %w", err)
+	}
+
+	// Если ни одна строка не была обновлена - задача не найдена
+	if count == 0 {
+		return fmt.Errorf("task with ID %s: %w", id, ErrTaskNotFound)
+	}
+
+	return nil
+}
+
+// GetTasksByStatus получает задачи в указанном состоянии, упорядоченные по дате, при равных датах - по ID.
+// Параметры:
+// db - соединение с базой данных;
+// status - состояние задач (StatusPending или StatusDone; пустая строка - любое);
+// includeArchived - включать ли архивные задачи;
+// limit - максимальное количество возвращаемых задач;
+// offset - количество пропускаемых задач (для постраничного вывода).
+// Возвращает:
+// слайс указателей на структуры Task и ошибку (если возникла).
+func GetTasksByStatus(db *sql.DB, status string, includeArchived bool, limit, offset int) ([]*Task, error) {
+	// Проверяем, что limit положительный
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than 0")
+	}
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+	return queryTasks(db, querySelectTasksByStatus, includeArchived, status, status, limit, offset)
+}
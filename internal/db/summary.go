@@ -0,0 +1,71 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// TaskSummary - облегчённое представление задачи для списка: без комментария,
+// который может быть большим и в списке не нужен. Полная задача доступна через GetTask.
+type TaskSummary struct {
+	ID     string `json:"id"`
+	Date   string `json:"date"`
+	Title  string `json:"title"`
+	Repeat string `json:"repeat,omitempty"`
+}
+
+// querySelectTaskSummaries выбирает только колонки, нужные для списка задач.
+// Сортировка по дате и ID делает постраничную выборку (offset) стабильной.
+const querySelectTaskSummaries = `
+	SELECT id, date, title, repeat
+	FROM scheduler
+	WHERE archived = 0 AND status = 'pending'
+	ORDER BY date ASC, id ASC
+	LIMIT ? OFFSET ?
+`
+
+// GetTaskSummaries получает облегчённый список активных (не архивных) задач.
+// Параметры:
+// db - соединение с базой данных;
+// limit - максимальное количество возвращаемых задач;
+// offset - количество пропускаемых задач (для постраничного вывода).
+// Возвращает:
+// слайс указателей на структуры TaskSummary и ошибку (если возникла).
+func GetTaskSummaries(db *sql.DB, limit, offset int) ([]*TaskSummary, error) {
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than 0")
+	}
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+
+	rows, err := db.Query(querySelectTaskSummaries, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := []*TaskSummary{}
+	for rows.Next() {
+		var summary TaskSummary
+		if err := rows.Scan(&summary.ID, &summary.Date, &summary.Title, &summary.Repeat); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, &summary)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
+// Summary возвращает облегчённое представление задачи.
+func (t *Task) Summary() *TaskSummary {
+	return &TaskSummary{
+		ID:     t.ID,
+		Date:   t.Date,
+		Title:  t.Title,
+		Repeat: t.Repeat,
+	}
+}
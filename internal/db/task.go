@@ -4,8 +4,24 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
+// ErrStaleTask - задача изменилась с момента чтения (например, её уже отметил выполненной параллельный запрос).
+var ErrStaleTask = errors.New("task was modified concurrently")
+
+// ErrTaskNotFound - задача с указанным ID отсутствует в базе данных.
+// Обработчики преобразуют её в HTTP 404 (Not Found).
+var ErrTaskNotFound = errors.New("task not found")
+
+// ErrConflict - ошибка нарушения ограничения целостности БД (например, уникального индекса).
+// Обработчики преобразуют её в HTTP 409 (Conflict).
+var ErrConflict = errors.New("task conflicts with existing data")
+
 // Структура Task представляет задачу в планировщике.
 // Поля соответствуют колонкам таблицы scheduler в базе данных.
 type Task struct {
@@ -14,41 +30,125 @@ type Task struct {
 	Title   string `json:"title"`
 	Comment string `json:"comment,omitempty"`
 	Repeat  string `json:"repeat,omitempty"`
+	// Archived - признак архивной задачи: такие задачи скрыты из основного списка, но не удалены.
+	Archived bool `json:"archived,omitempty"`
+	// Color - цвет метки задачи для интерфейса (#RRGGBB или название из палитры).
+	Color string `json:"color,omitempty"`
+	// DurationMinutes - оценка длительности задачи в минутах (0 - оценка не задана).
+	DurationMinutes int `json:"duration_minutes,omitempty"`
+	// CreatedAt и UpdatedAt - время создания и последнего изменения задачи в формате RFC 3339 (UTC);
+	// пустая строка - время неизвестно (задачи, созданные до появления этих колонок).
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// Status - состояние задачи: StatusPending (активна) или StatusDone (разовая задача выполнена).
+	Status string `json:"status,omitempty"`
+}
+
+// timestamp форматирует момент времени для колонок created_at и updated_at (RFC 3339, UTC).
+func timestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// taskColumns - колонки таблицы scheduler в порядке сканирования в структуру Task (см. scanTask).
+const taskColumns = `id, date, title, comment, repeat, archived, color, duration_minutes, created_at, updated_at, status`
+
+// rowScanner - общий интерфейс *sql.Row и *sql.Rows для сканирования строки.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTask сканирует строку с колонками taskColumns в структуру task.
+// Пустая дата (строки, не исправленные FixEmptyDates) считается сегодняшней,
+// чтобы такие задачи не ломали разбор дат в обработчиках.
+func scanTask(row rowScanner, task *Task) error {
+	var date, createdAt, updatedAt, status sql.NullString
+	if err := row.Scan(&task.ID, &date, &task.Title, &task.Comment, &task.Repeat, &task.Archived, &task.Color, &task.DurationMinutes, &createdAt, &updatedAt, &status); err != nil {
+		return err
+	}
+	task.CreatedAt, task.UpdatedAt = createdAt.String, updatedAt.String
+	task.Status = status.String
+	if task.Status == "" {
+		task.Status = StatusPending
+	}
+	task.Date = date.String
+	if task.Date == "" {
+		task.Date = time.Now().Format(dateLayout)
+	}
+	return nil
 }
 
 const (
 	queryInsertTask = `
 		INSERT INTO scheduler
-		(date, title, comment, repeat)
-		VALUES (?, ?, ?, ?)
+		(date, title, comment, repeat, color, duration_minutes, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	querySelectTask = `
-		SELECT id, date, title, comment, repeat
+		SELECT ` + taskColumns + `
 		FROM scheduler
 		WHERE id = ?
 	`
 	querySelectTasks = `
-		SELECT id, date, title, comment, repeat
+		SELECT ` + taskColumns + `
+		FROM scheduler
+		WHERE archived = 0 AND status = 'pending'
+		ORDER BY date ASC, id ASC
+		LIMIT ? OFFSET ?
+	`
+	querySelectArchivedTasks = `
+		SELECT ` + taskColumns + `
 		FROM scheduler
+		WHERE archived = 1
+		ORDER BY date ASC, id ASC
 		LIMIT ?
 	`
+	querySelectTasksByRepeat = `
+		SELECT ` + taskColumns + `
+		FROM scheduler
+		WHERE COALESCE(repeat, '') = ? AND (archived = 0 OR ?) AND (? = '' OR status = ?)
+		ORDER BY date ASC, id ASC
+		LIMIT ? OFFSET ?
+	`
+	queryUpdateArchived = `
+		UPDATE scheduler
+		SET archived = ?, updated_at = ?
+		WHERE id = ?
+	`
 	queryUpdateTask = `
 		UPDATE scheduler
-		SET date = ?, title = ?, comment = ?, repeat = ?
+		SET date = ?, title = ?, comment = ?, repeat = ?, color = ?, duration_minutes = ?, updated_at = ?
 		WHERE id = ?
 	`
 	queryUpdateDate = `
 		UPDATE scheduler
-		SET date = ?
+		SET date = ?, updated_at = ?
 		WHERE id = ?
 	`
+	queryAdvanceDate = `
+		UPDATE scheduler
+		SET date = ?, updated_at = ?
+		WHERE id = ? AND date = ?
+	`
 	queryDeleteTask = `
 		DELETE FROM scheduler
 		WHERE id = ?
 	`
 )
 
+// conflictError проверяет, является ли ошибка SQLite нарушением ограничения (SQLITE_CONSTRAINT),
+// и в этом случае оборачивает её в ErrConflict. Остальные ошибки возвращаются без изменений.
+func conflictError(err error) error {
+	var sqliteErr *sqlite.Error
+	// Младший байт кода содержит основной код ошибки, старшие - расширенный (UNIQUE, NOT NULL и тд.)
+	if errors.As(err, &sqliteErr) && sqliteErr.Code()&0xff == sqlite3.SQLITE_CONSTRAINT {
+		return fmt.Errorf("%w: %v", ErrConflict, err)
+	}
+	return err
+}
+
 // AddTask добавляет новую задачу в базу данных.
+// Время создания и изменения задачи (CreatedAt, UpdatedAt) задаётся текущим и записывается в task;
+// новая задача получает состояние StatusPending (значение колонки по умолчанию).
 // Параметры:
 // db - соединение с базой данных;
 // task - указатель на структуру Task с данными задачи.
@@ -60,10 +160,13 @@ func AddTask(db *sql.DB, task *Task) (int64, error) {
 		return 0, errors.New("task cannot be nil")
 	}
 
-	// Выполняем SQL-запрос на добавление задачи
-	res, err := db.Exec(queryInsertTask, task.Date, task.Title, task.Comment, task.Repeat)
+	// Выполняем SQL-запрос на добавление задачи (время создания и изменения совпадают)
+	task.CreatedAt = timestamp(time.Now())
+	task.UpdatedAt = task.CreatedAt
+	task.Status = StatusPending
+	res, err := db.Exec(queryInsertTask, task.Date, task.Title, task.Comment, task.Repeat, task.Color, task.DurationMinutes, task.CreatedAt, task.UpdatedAt)
 	if err != nil {
-		return 0, fmt.Errorf("failed to execute insert query: %w", err)
+		return 0, fmt.Errorf("failed to execute insert query: %w", conflictError(err))
 	}
 
 	// Получаем ID вновь созданной записи
@@ -75,6 +178,65 @@ func AddTask(db *sql.DB, task *Task) (int64, error) {
 	return id, err
 }
 
+// AddTasks добавляет несколько задач в одной транзакции: либо создаются все, либо ни одной.
+// Параметры:
+// db - соединение с базой данных;
+// tasks - задачи для добавления.
+// Возвращает:
+// ID вставленных записей в порядке задач и ошибку (если возникла).
+func AddTasks(db *sql.DB, tasks []*Task) ([]int64, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Откат не действует после успешного Commit
+	defer tx.Rollback()
+
+	now := timestamp(time.Now())
+	ids := make([]int64, 0, len(tasks))
+	for _, task := range tasks {
+		if task == nil {
+			return nil, errors.New("task cannot be nil")
+		}
+		res, err := tx.Exec(queryInsertTask, task.Date, task.Title, task.Comment, task.Repeat, task.Color, task.DurationMinutes, now, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute insert query: %w", conflictError(err))
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve last insert ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return ids, nil
+}
+
+// AddTaskReturning добавляет новую задачу в базу данных и возвращает её полностью.
+// Параметры:
+// db - соединение с базой данных;
+// task - указатель на структуру Task с данными задачи.
+// Возвращает:
+// указатель на копию задачи с присвоенным ID и ошибку (если возникла).
+func AddTaskReturning(db *sql.DB, task *Task) (*Task, error) {
+	if task == nil {
+		return nil, errors.New("task cannot be nil")
+	}
+
+	// Добавляем копию, чтобы не изменять переданную структуру
+	created := *task
+	id, err := AddTask(db, &created)
+	if err != nil {
+		return nil, err
+	}
+
+	created.ID = strconv.FormatInt(id, 10)
+	return &created, nil
+}
+
 // GetTask получает задачу из базы данных по её ID.
 // Параметры:
 // db - соединение с базой данных;
@@ -91,12 +253,12 @@ func GetTask(db *sql.DB, id string) (*Task, error) {
 	var task Task
 
 	// Выполняем запрос и сканируем результат в структуру task
-	err := db.QueryRow(querySelectTask, id).Scan(&task.ID, &task.Date, &task.Title, &task.Comment, &task.Repeat)
+	err := scanTask(db.QueryRow(querySelectTask, id), &task)
 
 	// Проверяем, не было ли ошибок при итерации по строкам
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("task with ID %s not found", id)
+			return nil, fmt.Errorf("task with ID %s: %w", id, ErrTaskNotFound)
 		}
 		return nil, fmt.Errorf("failed to scan task data: %w", err)
 	}
@@ -104,23 +266,40 @@ func GetTask(db *sql.DB, id string) (*Task, error) {
 	return &task, nil
 }
 
-// GetTasks получает список задач из базы данных с ограничением по количеству.
+// GetTasks получает список активных (не архивных) задач из базы данных с ограничением по количеству.
+// Задачи упорядочены по дате (ближайшие - первыми), при равных датах - по ID.
 // Параметры:
 // db - соединение с базой данных;
-// limit - максимальное количество возвращаемых задач.
+// limit - максимальное количество возвращаемых задач;
+// offset - количество пропускаемых задач (для постраничного вывода).
 // Возвращает:
 // слайс указателей на структуры Task и ошибку (если возникла).
-func GetTasks(db *sql.DB, limit int) ([]*Task, error) {
+func GetTasks(db *sql.DB, limit, offset int) ([]*Task, error) {
 	// Проверяем, что limit не равен нулю
 	if limit == 0 {
 		return nil, errors.New("limit must be greater than 0")
 	}
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+
+	// Выполняем запрос с ограничением на количество записей
+	return queryTasks(db, querySelectTasks, limit, offset)
+}
 
+// queryTasks выполняет SELECT-запрос, возвращающий колонки задачи (taskColumns),
+// и сканирует результат в слайс задач.
+// Параметры:
+// db - соединение с базой данных;
+// query - текст SQL-запроса;
+// args - аргументы запроса.
+// Возвращает:
+// слайс указателей на структуры Task и ошибку (если возникла).
+func queryTasks(db *sql.DB, query string, args ...any) ([]*Task, error) {
 	// Создаём пустой слайс для хранения задач
 	var tasks []*Task
 
-	// Выполняем запрос с ограничением на количество записей
-	rows, err := db.Query(querySelectTasks, limit)
+	rows, err := db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +311,7 @@ func GetTasks(db *sql.DB, limit int) ([]*Task, error) {
 		// Создаём локальную переменную для новой задачи
 		var task Task
 		// Сканируем данные текущей строки в структуру task
-		err := rows.Scan(&task.ID, &task.Date, &task.Title, &task.Comment, &task.Repeat)
+		err := scanTask(rows, &task)
 		if err != nil {
 			return nil, err
 		}
@@ -146,7 +325,72 @@ func GetTasks(db *sql.DB, limit int) ([]*Task, error) {
 	}
 
 	return tasks, nil
+}
+
+// GetArchivedTasks получает список архивных задач из базы данных (по возрастанию даты).
+// Параметры:
+// db - соединение с базой данных;
+// limit - максимальное количество возвращаемых задач.
+// Возвращает:
+// слайс указателей на структуры Task и ошибку (если возникла).
+func GetArchivedTasks(db *sql.DB, limit int) ([]*Task, error) {
+	// Проверяем, что limit положительный
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than 0")
+	}
+	return queryTasks(db, querySelectArchivedTasks, limit)
+}
+
+// GetTasksByRepeat получает задачи с указанным правилом повторения (точное совпадение).
+// Параметры:
+// db - соединение с базой данных;
+// repeat - правило повторения (пустая строка - задачи без повторения);
+// includeArchived - включать ли архивные задачи;
+// status - состояние задач (StatusPending или StatusDone; пустая строка - любое);
+// limit - максимальное количество возвращаемых задач;
+// offset - количество пропускаемых задач (для постраничного вывода).
+// Возвращает:
+// слайс указателей на структуры Task и ошибку (если возникла).
+func GetTasksByRepeat(db *sql.DB, repeat string, includeArchived bool, status string, limit, offset int) ([]*Task, error) {
+	// Проверяем, что limit положительный
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than 0")
+	}
+	if offset < 0 {
+		return nil, errors.New("offset must not be negative")
+	}
+	return queryTasks(db, querySelectTasksByRepeat, repeat, includeArchived, status, status, limit, offset)
+}
+
+// SetArchived переносит задачу в архив или возвращает её из архива.
+// Параметры:
+// db - соединение с базой данных;
+// id - идентификатор задачи;
+// archived - true для архивации, false для возврата из архива.
+// Возвращает ошибку, если операция не удалась.
+func SetArchived(db *sql.DB, id string, archived bool) error {
+	// Валидация входных данных: ID не должен быть пустым
+	if id == "" {
+		return errors.New("task ID must not be empty")
+	}
+
+	res, err := db.Exec(queryUpdateArchived, archived, timestamp(time.Now()), id)
+	if err != nil {
+		return fmt.Errorf("failed to execute archive update query: %w", err)
+	}
+
+	// Получаем количество затронутых строк (должно быть 1 для успешного обновления)
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve rows affected count: %w", err)
+	}
+
+	// Если ни одна строка не была обновлена - задача не найдена
+	if count == 0 {
+		return fmt.Errorf("task with ID %s: %w", id, ErrTaskNotFound)
+	}
 
+	return nil
 }
 
 // UpdateTask обновляет данные задачи в базе данных.
@@ -156,9 +400,9 @@ func GetTasks(db *sql.DB, limit int) ([]*Task, error) {
 // Возвращает ошибку, если операция не удалась.
 func UpdateTask(db *sql.DB, task *Task) error {
 	// Выполняем SQL-запрос на обновление задачи
-	res, err := db.Exec(queryUpdateTask, task.Date, task.Title, task.Comment, task.Repeat, task.ID)
+	res, err := db.Exec(queryUpdateTask, task.Date, task.Title, task.Comment, task.Repeat, task.Color, task.DurationMinutes, timestamp(time.Now()), task.ID)
 	if err != nil {
-		return fmt.Errorf("failed to execute update query: %w", err)
+		return fmt.Errorf("failed to execute update query: %w", conflictError(err))
 	}
 
 	// Получаем количество затронутых строк (должно быть 1 для успешного обновления)
@@ -169,7 +413,7 @@ func UpdateTask(db *sql.DB, task *Task) error {
 
 	// Если ни одна строка не была обновлена - задача не найдена
 	if count == 0 {
-		return fmt.Errorf("task with ID %s not found", task.ID)
+		return fmt.Errorf("task with ID %s: %w", task.ID, ErrTaskNotFound)
 	}
 
 	return nil
@@ -188,9 +432,9 @@ func UpdateDate(db *sql.DB, next string, id string) error {
 	}
 
 	// Выполняем SQL-запрос на обновление даты задачи
-	res, err := db.Exec(queryUpdateDate, next, id)
+	res, err := db.Exec(queryUpdateDate, next, timestamp(time.Now()), id)
 	if err != nil {
-		return fmt.Errorf("failed to execute date update query: %w", err)
+		return fmt.Errorf("failed to execute date update query: %w", conflictError(err))
 	}
 
 	// Получаем количество затронутых строк (должно быть 1 для успешного обновления)
@@ -201,12 +445,42 @@ func UpdateDate(db *sql.DB, next string, id string) error {
 
 	// Если ни одна строка не была обновлена - задача не найдена
 	if count == 0 {
-		return fmt.Errorf("task with ID %s not found", id)
+		return fmt.Errorf("task with ID %s: %w", id, ErrTaskNotFound)
 	}
 
 	return nil
 }
 
+// AdvanceDate переносит задачу на новую дату, только если её текущая дата не изменилась с момента чтения.
+// Условное обновление делает перенос атомарным: из параллельных запросов, прочитавших одну и ту же дату,
+// дату изменит только один, остальные получат ErrStaleTask.
+// Параметры:
+// db - соединение с базой данных;
+// id - идентификатор задачи;
+// from - дата задачи, прочитанная перед вычислением новой;
+// next - новая дата.
+// Возвращает ErrStaleTask, если задача не найдена или её дата уже изменилась, либо другую ошибку.
+func AdvanceDate(db *sql.DB, id, from, next string) error {
+	// Валидация входных данных: ID не должен быть пустым
+	if id == "" {
+		return errors.New("task ID must not be empty")
+	}
+
+	res, err := db.Exec(queryAdvanceDate, next, timestamp(time.Now()), id, from)
+	if err != nil {
+		return fmt.Errorf("failed to execute date update query: %w", conflictError(err))
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve rows affected count: %w", err)
+	}
+	if count == 0 {
+		return ErrStaleTask
+	}
+	return nil
+}
+
 // DeleteTask удаляет задачу из базы данных по ID.
 // Параметры:
 // db - соединение с базой данных;
@@ -232,7 +506,7 @@ func DeleteTask(db *sql.DB, id string) error {
 
 	// Если ни одна строка не была удалена - задача не найдена
 	if count == 0 {
-		return fmt.Errorf("no task with ID %s exists in the database", id)
+		return fmt.Errorf("task with ID %s: %w", id, ErrTaskNotFound)
 	}
 
 	return nil
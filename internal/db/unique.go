@@ -0,0 +1,47 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+)
+
+// SQL-скрипты для уникального индекса по паре (date, title).
+const (
+	queryFindDuplicateTitle = `SELECT date, title FROM scheduler GROUP BY date, title HAVING COUNT(*) > 1 LIMIT 1`
+	createUniqueTitleSQL    = `CREATE UNIQUE INDEX IF NOT EXISTS idx_scheduler_date_title ON scheduler (date, title);`
+	dropUniqueTitleSQL      = `DROP INDEX IF EXISTS idx_scheduler_date_title;`
+)
+
+// SetUniqueTitlePerDate включает или отключает уникальность заголовка задачи в пределах одной даты.
+// При включении создаётся уникальный индекс по (date, title); если в таблице уже есть задачи
+// с одинаковыми заголовком и датой, индекс не создаётся и возвращается ошибка с примером дубликата.
+// При отключении индекс удаляется, если он был создан ранее.
+// Параметры:
+// db - соединение с базой данных;
+// enabled - требуется ли уникальность.
+// Возвращает ошибку, если схему не удалось изменить.
+func SetUniqueTitlePerDate(db *sql.DB, enabled bool) error {
+	if !enabled {
+		if _, err := db.Exec(dropUniqueTitleSQL); err != nil {
+			return fmt.Errorf("failed to drop unique title index: %w", err)
+		}
+		return nil
+	}
+
+	// Проверяем существующие данные, чтобы вернуть понятную ошибку вместо ошибки SQLite
+	var date, title string
+	err := db.QueryRow(queryFindDuplicateTitle).Scan(&date, &title)
+	switch {
+	case err == nil:
+		return fmt.Errorf("cannot enforce unique titles per date: title %q is used more than once on %s", title, date)
+	case err != sql.ErrNoRows:
+		return fmt.Errorf("failed to check for duplicate titles: %w", err)
+	}
+
+	if _, err := db.Exec(createUniqueTitleSQL); err != nil {
+		return fmt.Errorf("failed to create unique title index: %w", err)
+	}
+	log.Println("Включена уникальность заголовков задач в пределах даты")
+	return nil
+}
@@ -0,0 +1,48 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+const (
+	querySize       = `SELECT page_count * page_size FROM pragma_page_count(), pragma_page_size()`
+	queryCountTasks = `SELECT COUNT(*) FROM scheduler`
+	queryVacuum     = `VACUUM`
+)
+
+// Size возвращает размер БД в байтах (количество страниц, умноженное на размер страницы).
+// Для файловой БД совпадает с размером основного файла.
+// Параметры:
+// db - соединение с базой данных.
+// Возвращает размер и ошибку (если возникла).
+func Size(db *sql.DB) (int64, error) {
+	var size int64
+	if err := db.QueryRow(querySize).Scan(&size); err != nil {
+		return 0, fmt.Errorf("failed to read database size: %w", err)
+	}
+	return size, nil
+}
+
+// CountTasks возвращает количество задач в таблице scheduler (включая архивные).
+// Параметры:
+// db - соединение с базой данных.
+// Возвращает количество и ошибку (если возникла).
+func CountTasks(db *sql.DB) (int64, error) {
+	var count int64
+	if err := db.QueryRow(queryCountTasks).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count tasks: %w", err)
+	}
+	return count, nil
+}
+
+// Vacuum перестраивает файл БД командой VACUUM, освобождая место, оставшееся после удалённых строк.
+// Параметры:
+// db - соединение с базой данных.
+// Возвращает ошибку, если операция не удалась.
+func Vacuum(db *sql.DB) error {
+	if _, err := db.Exec(queryVacuum); err != nil {
+		return fmt.Errorf("failed to vacuum database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxCheckYears - максимальное количество лет, перебираемых при проверке правила "y".
+const maxCheckYears = 10000
+
+// CheckRepeat проверяет, является ли дата `date` повторением по правилу `repeat`, и объясняет причину несовпадения.
+// Для правил "w" и "m" совпадение определяется самой датой; для правил "d", "h" и "y" нужна стартовая дата
+// задачи `dstart`: повторения отсчитываются от неё так же, как в NextDate.
+// Параметры:
+// date - проверяемая дата (в формате DateFormat);
+// dstart - стартовая дата задачи в формате DateFormat (обязательна для "d" и "y");
+// repeat - правило повторения.
+// Возвращает:
+// - true, если дата совпадает с правилом;
+// - пояснение на английском языке (почему дата не совпадает или что она совпадает);
+// - ошибку, если правило или стартовая дата некорректны.
+func CheckRepeat(date string, dstart string, repeat string) (bool, string, error) {
+	day, err := time.Parse(DateFormat, date)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to parse date: %w", err)
+	}
+	if repeat == "" {
+		return false, "", errors.New("repeat rule is missing")
+	}
+
+	parts := strings.Split(repeat, " ")
+	limits := CurrentRepeatLimits()
+
+	switch parts[0] {
+	case "d", "h", "y":
+		if dstart == "" {
+			return false, "", fmt.Errorf("rule '%s' requires a start date", parts[0])
+		}
+		start, err := time.Parse(DateFormat, dstart)
+		if err != nil {
+			return false, "", fmt.Errorf("failed to parse start date: %w", err)
+		}
+		if parts[0] == "d" {
+			if len(parts) != 2 {
+				return false, "", errors.New("rule 'd' requires exactly one numeric value")
+			}
+			interval, err := strconv.Atoi(parts[1])
+			if err != nil || interval <= 0 || interval > limits.MaxDays {
+				return false, "", fmt.Errorf("interval must be an integer in range [1, %d]", limits.MaxDays)
+			}
+			return checkDays(day, start, interval), explainDays(day, start, interval), nil
+		}
+		if parts[0] == "h" {
+			if len(parts) != 2 {
+				return false, "", errors.New("rule 'h' requires exactly one numeric value")
+			}
+			interval, err := strconv.Atoi(parts[1])
+			if err != nil {
+				return false, "", fmt.Errorf("interval must be a valid integer: %w", err)
+			}
+			if err := checkHours(interval, limits); err != nil {
+				return false, "", err
+			}
+			return checkHourRule(day, start, interval)
+		}
+		if len(parts) != 1 {
+			return false, "", errors.New("rule 'y' takes no values")
+		}
+		return checkYears(day, start)
+
+	case "w":
+		if len(parts) != 2 {
+			return false, "", errors.New("rule 'w' requires comma-separated list of weekdays")
+		}
+		weekdays, err := parseList(parts[1], 1, 7)
+		if err != nil {
+			return false, "", err
+		}
+		// Номер дня недели в правиле: 1 - понедельник, ..., 7 - воскресенье
+		weekday := int(day.Weekday())
+		if weekday == 0 {
+			weekday = 7
+		}
+		matches := false
+		names := make([]string, 0, len(weekdays))
+		for _, d := range weekdays {
+			if d == weekday {
+				matches = true
+			}
+			names = append(names, weekdayNamesEn[d-1])
+		}
+		if matches {
+			return true, fmt.Sprintf("date is a %s and rule matches %s", weekdayNamesEn[weekday-1], strings.Join(names, ", ")), nil
+		}
+		return false, fmt.Sprintf("date is a %s but rule matches only %s", weekdayNamesEn[weekday-1], strings.Join(names, ", ")), nil
+
+	case "m":
+		if len(parts) < 2 || len(parts) > 3 {
+			return false, "", errors.New("rule 'm' requires a list of days of the month and an optional list of months")
+		}
+		days, err := parseList(parts[1], -2, limits.MaxMonthDay, 0)
+		if err != nil {
+			return false, "", err
+		}
+		if len(parts) == 3 {
+			months, err := parseList(parts[2], 1, 12)
+			if err != nil {
+				return false, "", err
+			}
+			monthMatches := false
+			names := make([]string, 0, len(months))
+			for _, m := range months {
+				if m == int(day.Month()) {
+					monthMatches = true
+				}
+				names = append(names, monthNamesEn[m-1])
+			}
+			if !monthMatches {
+				return false, fmt.Sprintf("date is in %s but rule matches only %s", monthNamesEn[day.Month()-1], strings.Join(names, ", ")), nil
+			}
+		}
+		if !matchesMDay(day, days, monthDayClamp.Load()) {
+			return false, fmt.Sprintf("date is day %d of the month but rule matches only %s", day.Day(), describeMonthDays(days)), nil
+		}
+		return true, fmt.Sprintf("date is day %d of the month and rule matches %s", day.Day(), describeMonthDays(days)), nil
+
+	default:
+		return false, "", fmt.Errorf("unsupported repeat rule: %s", parts[0])
+	}
+}
+
+// checkDays проверяет, попадает ли `day` на повторение правила "d interval", начатого в `start`.
+func checkDays(day, start time.Time, interval int) bool {
+	diff := int(day.Sub(start).Hours() / 24)
+	return diff >= 0 && diff%interval == 0
+}
+
+// explainDays формирует пояснение для правила "d".
+func explainDays(day, start time.Time, interval int) string {
+	diff := int(day.Sub(start).Hours() / 24)
+	switch {
+	case diff < 0:
+		return fmt.Sprintf("date is %d days before the start date %s", -diff, start.Format(DateFormat))
+	case diff%interval == 0:
+		return fmt.Sprintf("date is %d days after the start date and rule repeats every %d days", diff, interval)
+	default:
+		return fmt.Sprintf("date is %d days after the start date but rule repeats every %d days (nearest occurrences: %s and %s)",
+			diff, interval,
+			start.AddDate(0, 0, diff/interval*interval).Format(DateFormat),
+			start.AddDate(0, 0, (diff/interval+1)*interval).Format(DateFormat))
+	}
+}
+
+// checkHourRule проверяет правило "h": дата совпадает, если на неё приходится хотя бы одно повторение,
+// отсчитанное в часах от полуночи `start`, как в NextDate.
+func checkHourRule(day, start time.Time, interval int) (bool, string, error) {
+	diff := int(day.Sub(start).Hours() / 24)
+	if diff < 0 {
+		return false, fmt.Sprintf("date is %d days before the start date %s", -diff, start.Format(DateFormat)), nil
+	}
+	occurrence := hourOccurrence(start, day, interval)
+	if occurrence.Before(day.Add(24 * time.Hour)) {
+		return true, fmt.Sprintf("date is %d days after the start date and rule repeats every %d hours (occurrence at %s)",
+			diff, interval, occurrence.Format("15:04")), nil
+	}
+	previous := occurrence.Add(-time.Duration(interval) * time.Hour)
+	return false, fmt.Sprintf("date is %d days after the start date but rule repeats every %d hours (nearest occurrences: %s and %s)",
+		diff, interval, previous.Format(DateFormat), occurrence.Format(DateFormat)), nil
+}
+
+// checkYears проверяет правило "y": повторения получаются сдвигом на год от предыдущего повторения, как в NextDate.
+func checkYears(day, start time.Time) (bool, string, error) {
+	if day.Before(start) {
+		return false, fmt.Sprintf("date is before the start date %s", start.Format(DateFormat)), nil
+	}
+	policy := CurrentLeapDayPolicy()
+	occurrence := start
+	for years := 1; occurrence.Before(day); years++ {
+		if years > maxCheckYears {
+			return false, "", errors.New("date is too far from the start date")
+		}
+		occurrence = nextYearOccurrence(occurrence, policy)
+	}
+	if occurrence.Equal(day) {
+		return true, fmt.Sprintf("date is %s %d and rule repeats every year from %s", monthNamesEn[day.Month()-1], day.Day(), start.Format(DateFormat)), nil
+	}
+	return false, fmt.Sprintf("date is %s %d but rule repeats every year on %s %d", monthNamesEn[day.Month()-1], day.Day(), monthNamesEn[start.Month()-1], start.Day()), nil
+}
+
+// describeMonthDays перечисляет дни месяца правила "m" на английском языке.
+func describeMonthDays(days []int) string {
+	names := make([]string, 0, len(days))
+	for _, d := range days {
+		switch d {
+		case -1:
+			names = append(names, "the last day")
+		case -2:
+			names = append(names, "the second-to-last day")
+		default:
+			names = append(names, "day "+strconv.Itoa(d))
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -12,6 +13,24 @@ import (
 // Используем для парсинга и форматирования дат в строковом представлении.
 const DateFormat = "20060102"
 
+// ISODateFormat - формат даты ISO 8601 (YYYY-MM-DD), используемый при вводе и выводе дат в API.
+const ISODateFormat = "2006-01-02"
+
+// maxMonthSearchDays - максимальное количество дней, просматриваемых при поиске даты по правилу "m".
+// Десяти лет достаточно для любой существующей даты (включая 29 февраля); если совпадения нет,
+// правило невыполнимо (например, "m 31 2" без клампинга), и NextDate возвращает ошибку.
+const maxMonthSearchDays = 366 * 10
+
+// monthDayClamp включает клампинг дней месяца в правиле "m": день, которого нет в месяце
+// (например, 31 в апреле), считается последним днём этого месяца. По умолчанию выключен -
+// такие месяцы пропускаются.
+var monthDayClamp atomic.Bool
+
+// SetMonthDayClamp включает или выключает клампинг дней месяца в правиле "m".
+func SetMonthDayClamp(enabled bool) {
+	monthDayClamp.Store(enabled)
+}
+
 // AfterNow проверяет, наступает ли дата `date` позже, чем `now`.
 // Параметры:
 // date - проверяемая дата.
@@ -29,9 +48,10 @@ func AfterNow(date, now time.Time) bool {
 // matchesMDay проверяет, соответствует ли дата `date` одному из указанных дней месяца.
 // Параметры:
 // date - проверяемая дата.
-// days - список допустимых дней месяца (положительные числа 1–31, -1 - последний день месяца, -2 - предпоследний день).
+// days - список допустимых дней месяца (положительные числа 1–31, -1 - последний день месяца, -2 - предпоследний день);
+// clamp - считать день, превышающий длину месяца, последним днём месяца.
 // Возвращает: true, если дата соответствует одному из указанных дней, иначе false.
-func matchesMDay(date time.Time, days []int) bool {
+func matchesMDay(date time.Time, days []int, clamp bool) bool {
 	year, month, _ := date.Date()
 
 	// Получаем последний день месяца: создаём дату первого дня следующего месяца и вычитаем один день.
@@ -45,6 +65,10 @@ func matchesMDay(date time.Time, days []int) bool {
 			if date.Day() == day {
 				return true
 			}
+			// При клампинге день за пределами месяца совпадает с последним днём месяца
+			if clamp && day > lastDay && date.Day() == lastDay {
+				return true
+			}
 		// Если указан -1, проверяем, является ли дата последним днём месяца.
 		case day == -1:
 			if date.Day() == lastDay {
@@ -62,10 +86,12 @@ func matchesMDay(date time.Time, days []int) bool {
 }
 
 // NextDate вычисляет следующую дату по правилу повторения, начиная с `dstart`.
+// Если `dstart` уже позже `now`, первым повторением считается сама стартовая дата: для правил "d", "h" и "y"
+// она возвращается без изменений, а для "w" и "m" поиск подходящего дня начинается с неё (включительно).
 // Параметры:
 // now - текущая дата и время (используется для сравнения).
 // dstart - начальная дата в формате DateFormat (строка).
-// repeat - правило повторения в виде строки (например, "d 7", "y", "w 1,2", "m 1,15 1,3,5").
+// repeat - правило повторения в виде строки (например, "d 7", "h 6", "y", "w 1,2", "m 1,15 1,3,5").
 // Возвращает:
 // - следующую подходящую дату в формате DateFormat (строка);
 // - ошибку при некорректных входных данных или невозможности вычисления даты.
@@ -85,7 +111,10 @@ func NextDate(now time.Time, dstart string, repeat string) (string, error) {
 	// Разбиваем правило повторения на части по пробелам для дальнейшей обработки.
 	parts := strings.Split(repeat, " ")
 
-	// Обрабатываем разные типы правил повторения (d, y, w, m).
+	// Границы числовых аргументов правил (настраиваются через SetRepeatLimits).
+	limits := CurrentRepeatLimits()
+
+	// Обрабатываем разные типы правил повторения (d, h, y, w, m).
 	switch parts[0] {
 	case "d":
 		// Для правила "d" (дни) нужно ровно 2 части: "d" и число интервала.
@@ -99,25 +128,45 @@ func NextDate(now time.Time, dstart string, repeat string) (string, error) {
 			return "", fmt.Errorf("interval must be a valid integer: %w", err)
 		}
 
-		// Проверяем допустимый диапазон интервала (1-400 дней).
-		if interval <= 0 || interval > 400 {
-			return "", errors.New("interval must be in range [1, 400]")
+		// Проверяем допустимый диапазон интервала (по умолчанию 1-400 дней).
+		if interval <= 0 || interval > limits.MaxDays {
+			return "", fmt.Errorf("interval must be in range [1, %d]", limits.MaxDays)
 		}
 
-		// Увеличиваем дату на интервал в цикле, пока она не станет строго больше `now`.
-		for {
+		// Увеличиваем дату на интервал, пока она не станет строго больше `now`
+		// (стартовая дата, которая уже позже `now`, возвращается без изменений).
+		for !AfterNow(date, now) {
 			date = date.AddDate(0, 0, interval)
-			if AfterNow(date, now) {
-				break
-			}
+		}
+	case "h":
+		// Для правила "h" (часы) нужно ровно 2 части: "h" и число интервала.
+		if len(parts) != 2 {
+			return "", errors.New("rule 'h' requires exactly one numeric value")
+		}
+
+		// Преобразуем интервал из строки в число (количество часов).
+		interval, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return "", fmt.Errorf("interval must be a valid integer: %w", err)
+		}
+
+		// Интервал ограничен той же длительностью, что и правило "d" (по умолчанию 1-9600 часов, то есть 400 дней).
+		if err := checkHours(interval, limits); err != nil {
+			return "", err
+		}
+
+		// Время суток в задаче не хранится, поэтому повторения отсчитываются в часах от полуночи стартовой даты,
+		// а следующей датой становится первый календарный день после `now`, на который приходится повторение.
+		if !AfterNow(date, now) {
+			tomorrow := now.Truncate(24 * time.Hour).Add(24 * time.Hour)
+			date = hourOccurrence(date, tomorrow, interval)
 		}
 	case "y":
-		// Для правила "y" (год) увеличиваем дату на 1 год в цикле, пока она не превысит `now`.
-		for {
-			date = date.AddDate(1, 0, 0)
-			if AfterNow(date, now) {
-				break
-			}
+		// Для правила "y" (год) сдвигаем дату на год, пока она не станет строго больше `now`.
+		// Для 29 февраля в невисокосные годы действует правило SetLeapDayPolicy (см. nextYearOccurrence).
+		policy := CurrentLeapDayPolicy()
+		for !AfterNow(date, now) {
+			date = nextYearOccurrence(date, policy)
 		}
 	case "w":
 		if len(parts) < 2 {
@@ -140,15 +189,11 @@ func NextDate(now time.Time, dstart string, repeat string) (string, error) {
 			}
 		}
 
-		// Начинаем поиск с завтрашнего дня относительно стартовой даты.
-		candidateDate := date.AddDate(0, 0, 1)
-
-		// Увеличиваем candidateDate, пока она не станет строго больше `now`.
-		for {
+		// Начинаем поиск со стартовой даты и сдвигаем кандидата, пока он не станет строго больше `now`
+		// (если стартовая дата уже позже `now`, она остаётся первым кандидатом).
+		candidateDate := date
+		for !AfterNow(candidateDate, now) {
 			candidateDate = candidateDate.AddDate(0, 0, 1)
-			if AfterNow(candidateDate, now) {
-				break
-			}
 		}
 
 		// Ищем ближайший подходящий день недели из списка `weekdays`.
@@ -184,9 +229,9 @@ func NextDate(now time.Time, dstart string, repeat string) (string, error) {
 			if err != nil {
 				return "", fmt.Errorf("day of month must be a valid integer: %s", s)
 			}
-			// Проверяем, что день находится в допустимом диапазоне: от -2 до 31.
-			if day < -2 || day > 31 {
-				return "", fmt.Errorf("day of month must be in range [-2, 31]: got %d", day)
+			// Проверяем, что день находится в допустимом диапазоне: по умолчанию от -2 до 31.
+			if day < -2 || day > limits.MaxMonthDay {
+				return "", fmt.Errorf("day of month must be in range [-2, %d]: got %d", limits.MaxMonthDay, day)
 			}
 			// Добавляем корректный день в слайс days.
 			days = append(days, day)
@@ -212,48 +257,36 @@ func NextDate(now time.Time, dstart string, repeat string) (string, error) {
 			}
 		}
 
-		// Начинаем поиск с завтрашнего дня относительно стартовой даты.
-		candidateDate := date.AddDate(0, 0, 1)
-
-		// Увеличиваем candidateDate, пока она не станет строго больше `now`.
-		for {
+		// Начинаем поиск со стартовой даты и сдвигаем кандидата, пока он не станет строго больше `now`
+		// (если стартовая дата уже позже `now`, она остаётся первым кандидатом).
+		candidateDate := date
+		for !AfterNow(candidateDate, now) {
 			candidateDate = candidateDate.AddDate(0, 0, 1)
-			if AfterNow(candidateDate, now) {
-				break
-			}
 		}
 
+		clamp := monthDayClamp.Load()
+
 		// Ищем ближайшую подходящую дату, соответствующую правилам дней и месяцев.
-	loopTwo:
-		for {
-			// Получаем номер месяца для candidateDate.
-			month := candidateDate.Month()
-			// Получаем число дня для candidateDate.
-			day := candidateDate.Day()
-
-			// Если месяцы не указаны, проверяем только соответствие дней.
-			if len(months) == 0 {
-				if matchesMDay(candidateDate, days) {
-					date = candidateDate
-					break loopTwo
-				}
+		for i := 0; ; i++ {
+			// Если за maxMonthSearchDays дней совпадений нет, правило невыполнимо.
+			if i > maxMonthSearchDays {
+				return "", fmt.Errorf("no date matches repeat rule: %s", repeat)
 			}
-			// Если месяцы указаны, проверяем совпадение и месяца, и дня.
-			found := false
+
+			// Если месяцы указаны, проверяем, входит ли месяц candidateDate в список.
+			monthMatches := len(months) == 0
 			for _, targetMonth := range months {
-				for _, targetDay := range days {
-					// Если текущий месяц и день совпадают с целевыми, фиксируем дату.
-					if int(month) == targetMonth && day == targetDay {
-						date = candidateDate
-						found = true
-						break
-					}
-				}
-				if found {
-					// Выходим из обоих циклов при нахождении подходящей даты.
-					break loopTwo
+				if int(candidateDate.Month()) == targetMonth {
+					monthMatches = true
+					break
 				}
 			}
+
+			// Если месяц подходит и день совпадает с одним из целевых, фиксируем дату.
+			if monthMatches && matchesMDay(candidateDate, days, clamp) {
+				date = candidateDate
+				break
+			}
 			// Если текущая дата не подошла, переходим к следующему дню.
 			candidateDate = candidateDate.AddDate(0, 0, 1)
 		}
@@ -265,3 +298,22 @@ func NextDate(now time.Time, dstart string, repeat string) (string, error) {
 	// Форматируем итоговую дату в требуемый строковый формат (YYYYMMDD).
 	return date.Format(DateFormat), nil
 }
+
+// checkHours проверяет интервал правила "h" в часах: от 1 до limits.MaxDays*24.
+func checkHours(interval int, limits RepeatLimits) error {
+	maxHours := limits.MaxDays * 24
+	if interval <= 0 || interval > maxHours {
+		return fmt.Errorf("interval must be in range [1, %d]", maxHours)
+	}
+	return nil
+}
+
+// hourOccurrence возвращает первое повторение правила "h interval", начатого в `start`, не раньше `from`.
+func hourOccurrence(start, from time.Time, interval int) time.Time {
+	if !from.After(start) {
+		return start
+	}
+	step := time.Duration(interval) * time.Hour
+	steps := (from.Sub(start) + step - 1) / step
+	return start.Add(steps * step)
+}
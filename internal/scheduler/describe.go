@@ -0,0 +1,199 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Поддерживаемые языки описаний правил повторения.
+const (
+	LangEnglish = "en"
+	LangRussian = "ru"
+)
+
+var (
+	weekdayNamesEn = [...]string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+	weekdayNamesRu = [...]string{"понедельник", "вторник", "среда", "четверг", "пятница", "суббота", "воскресенье"}
+	monthNamesEn   = [...]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+	monthNamesRu   = [...]string{"январь", "февраль", "март", "апрель", "май", "июнь", "июль", "август", "сентябрь", "октябрь", "ноябрь", "декабрь"}
+)
+
+// parseList разбирает список целых чисел через запятую и проверяет, что каждое значение входит в [min, max].
+// Значения из exclude недопустимы, даже если входят в диапазон.
+func parseList(s string, min, max int, exclude ...int) ([]int, error) {
+	var values []int
+	for _, item := range strings.Split(s, ",") {
+		v, err := strconv.Atoi(item)
+		if err != nil || v < min || v > max {
+			return nil, fmt.Errorf("invalid value %q: must be an integer in range [%d, %d]", item, min, max)
+		}
+		for _, e := range exclude {
+			if v == e {
+				return nil, fmt.Errorf("invalid value %q", item)
+			}
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// pluralRu выбирает форму русского слова для числа n (например, "день", "дня", "дней").
+func pluralRu(n int, one, few, many string) string {
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return one
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return few
+	default:
+		return many
+	}
+}
+
+// DescribeRepeat формирует человекочитаемое описание правила повторения (например, "d 7" - "Every 7 days").
+// Параметры:
+// repeat - правило повторения ("d <число>", "h <число>", "y", "w <дни недели>", "m <дни месяца> [<месяцы>]");
+// lang - язык описания (LangEnglish или LangRussian).
+// Возвращает:
+// описание правила и ошибку, если правило или язык не поддерживаются.
+func DescribeRepeat(repeat, lang string) (string, error) {
+	if lang != LangEnglish && lang != LangRussian {
+		return "", fmt.Errorf("unsupported language: %q", lang)
+	}
+	if repeat == "" {
+		return "", errors.New("repeat rule is missing")
+	}
+
+	ru := lang == LangRussian
+	parts := strings.Split(repeat, " ")
+	limits := CurrentRepeatLimits()
+
+	switch parts[0] {
+	case "d":
+		if len(parts) != 2 {
+			return "", errors.New("rule 'd' requires exactly one numeric value")
+		}
+		interval, err := strconv.Atoi(parts[1])
+		if err != nil || interval <= 0 || interval > limits.MaxDays {
+			return "", fmt.Errorf("interval must be an integer in range [1, %d]", limits.MaxDays)
+		}
+		switch {
+		case interval == 1 && ru:
+			return "Каждый день", nil
+		case interval == 1:
+			return "Every day", nil
+		case ru:
+			return fmt.Sprintf("Каждые %d %s", interval, pluralRu(interval, "день", "дня", "дней")), nil
+		default:
+			return fmt.Sprintf("Every %d days", interval), nil
+		}
+
+	case "h":
+		if len(parts) != 2 {
+			return "", errors.New("rule 'h' requires exactly one numeric value")
+		}
+		interval, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return "", fmt.Errorf("interval must be a valid integer: %w", err)
+		}
+		if err := checkHours(interval, limits); err != nil {
+			return "", err
+		}
+		switch {
+		case interval == 1 && ru:
+			return "Каждый час", nil
+		case interval == 1:
+			return "Every hour", nil
+		case ru:
+			return fmt.Sprintf("Каждые %d %s", interval, pluralRu(interval, "час", "часа", "часов")), nil
+		default:
+			return fmt.Sprintf("Every %d hours", interval), nil
+		}
+
+	case "y":
+		if len(parts) != 1 {
+			return "", errors.New("rule 'y' takes no values")
+		}
+		if ru {
+			return "Каждый год", nil
+		}
+		return "Every year", nil
+
+	case "w":
+		if len(parts) != 2 {
+			return "", errors.New("rule 'w' requires comma-separated list of weekdays")
+		}
+		weekdays, err := parseList(parts[1], 1, 7)
+		if err != nil {
+			return "", err
+		}
+		names := make([]string, 0, len(weekdays))
+		for _, d := range weekdays {
+			if ru {
+				names = append(names, weekdayNamesRu[d-1])
+			} else {
+				names = append(names, weekdayNamesEn[d-1])
+			}
+		}
+		if ru {
+			return "Каждую неделю: " + strings.Join(names, ", "), nil
+		}
+		return "Every week on " + strings.Join(names, ", "), nil
+
+	case "m":
+		if len(parts) < 2 || len(parts) > 3 {
+			return "", errors.New("rule 'm' requires a list of days of the month and an optional list of months")
+		}
+		days, err := parseList(parts[1], -2, limits.MaxMonthDay, 0)
+		if err != nil {
+			return "", err
+		}
+		dayNames := make([]string, 0, len(days))
+		for _, d := range days {
+			switch {
+			case d == -1 && ru:
+				dayNames = append(dayNames, "последний день")
+			case d == -1:
+				dayNames = append(dayNames, "the last day")
+			case d == -2 && ru:
+				dayNames = append(dayNames, "предпоследний день")
+			case d == -2:
+				dayNames = append(dayNames, "the second-to-last day")
+			case ru:
+				dayNames = append(dayNames, strconv.Itoa(d)+"-е число")
+			default:
+				dayNames = append(dayNames, "day "+strconv.Itoa(d))
+			}
+		}
+
+		description := "Every month on " + strings.Join(dayNames, ", ")
+		if ru {
+			description = "Каждый месяц: " + strings.Join(dayNames, ", ")
+		}
+
+		if len(parts) == 3 {
+			months, err := parseList(parts[2], 1, 12)
+			if err != nil {
+				return "", err
+			}
+			monthNames := make([]string, 0, len(months))
+			for _, m := range months {
+				if ru {
+					monthNames = append(monthNames, monthNamesRu[m-1])
+				} else {
+					monthNames = append(monthNames, monthNamesEn[m-1])
+				}
+			}
+			if ru {
+				description += "; месяцы: " + strings.Join(monthNames, ", ")
+			} else {
+				description += " in " + strings.Join(monthNames, ", ")
+			}
+		}
+		return description, nil
+
+	default:
+		return "", fmt.Errorf("unsupported repeat rule: %s", parts[0])
+	}
+}
@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// LeapDayPolicy - правило "y" для задачи, начатой 29 февраля, в невисокосные годы.
+// Повторения правила "y" вычисляются шагами по одному году от предыдущего повторения - так же,
+// как дата задачи сдвигается при отметке выполнения. Поэтому при LeapDayFeb28 задача, перенесённая на 28 февраля,
+// дальше повторяется 28 февраля и в високосные годы.
+type LeapDayPolicy string
+
+const (
+	// LeapDayFeb28 - в невисокосный год задача переносится на 28 февраля и остаётся на этой дате (по умолчанию).
+	LeapDayFeb28 LeapDayPolicy = "feb28"
+	// LeapDayLeapYears - невисокосные годы пропускаются, задача повторяется только 29 февраля.
+	LeapDayLeapYears LeapDayPolicy = "leap"
+)
+
+// leapDayPolicy - действующее правило для 29 февраля (nil - LeapDayFeb28).
+var leapDayPolicy atomic.Pointer[LeapDayPolicy]
+
+// ParseLeapDayPolicy разбирает правило для 29 февраля из строки ("feb28" или "leap").
+// Параметры:
+// value - значение (пустая строка - правило по умолчанию LeapDayFeb28).
+// Возвращает: правило и ошибку, если значение не поддерживается.
+func ParseLeapDayPolicy(value string) (LeapDayPolicy, error) {
+	switch policy := LeapDayPolicy(value); policy {
+	case "":
+		return LeapDayFeb28, nil
+	case LeapDayFeb28, LeapDayLeapYears:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("unsupported leap day policy %q: expected %q or %q", value, LeapDayFeb28, LeapDayLeapYears)
+	}
+}
+
+// SetLeapDayPolicy устанавливает правило для 29 февраля, применяемое в NextDate и CheckRepeat.
+func SetLeapDayPolicy(policy LeapDayPolicy) {
+	leapDayPolicy.Store(&policy)
+}
+
+// CurrentLeapDayPolicy возвращает действующее правило для 29 февраля.
+func CurrentLeapDayPolicy() LeapDayPolicy {
+	if policy := leapDayPolicy.Load(); policy != nil {
+		return *policy
+	}
+	return LeapDayFeb28
+}
+
+// isLeapYear проверяет, является ли год високосным.
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// nextYearOccurrence возвращает следующее повторение правила "y" после даты date (один шаг).
+// Шаг отсчитывается от date, а не от исходной даты задачи, поскольку исходная дата не хранится:
+// задача от 29 февраля при LeapDayFeb28 переходит на 28 февраля следующего года и дальше остаётся на 28 февраля,
+// а при LeapDayLeapYears переходит на 29 февраля ближайшего високосного года.
+// Параметры:
+// date - текущее повторение;
+// policy - правило для 29 февраля в невисокосные годы.
+// Возвращает: дату следующего повторения.
+func nextYearOccurrence(date time.Time, policy LeapDayPolicy) time.Time {
+	if date.Month() != time.February || date.Day() != 29 {
+		return date.AddDate(1, 0, 0)
+	}
+	if policy != LeapDayLeapYears {
+		return time.Date(date.Year()+1, time.February, 28, 0, 0, 0, 0, date.Location())
+	}
+
+	year := date.Year() + 1
+	for !isLeapYear(year) {
+		year++
+	}
+	return time.Date(year, time.February, 29, 0, 0, 0, 0, date.Location())
+}
@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// RepeatLimits - верхние границы числовых аргументов правил повторения.
+// Границы можно только сузить относительно DefaultRepeatLimits (например, запретить интервалы "d" больше 90 дней).
+type RepeatLimits struct {
+	MaxDays     int // Максимальный интервал правила "d" в днях
+	MaxMonthDay int // Максимальный положительный день месяца в правиле "m"
+}
+
+// DefaultRepeatLimits - границы аргументов правил повторения по умолчанию.
+var DefaultRepeatLimits = RepeatLimits{
+	MaxDays:     400,
+	MaxMonthDay: 31,
+}
+
+// repeatLimits - действующие границы аргументов правил повторения (nil - DefaultRepeatLimits).
+var repeatLimits atomic.Pointer[RepeatLimits]
+
+// Validate проверяет, что границы не выходят за пределы DefaultRepeatLimits.
+// Возвращает: ошибку с описанием некорректной границы или nil.
+func (l RepeatLimits) Validate() error {
+	if l.MaxDays < 1 || l.MaxDays > DefaultRepeatLimits.MaxDays {
+		return fmt.Errorf("maximum 'd' interval must be in range [1, %d]: got %d", DefaultRepeatLimits.MaxDays, l.MaxDays)
+	}
+	if l.MaxMonthDay < 1 || l.MaxMonthDay > DefaultRepeatLimits.MaxMonthDay {
+		return fmt.Errorf("maximum 'm' day must be in range [1, %d]: got %d", DefaultRepeatLimits.MaxMonthDay, l.MaxMonthDay)
+	}
+	return nil
+}
+
+// SetRepeatLimits устанавливает границы аргументов, применяемые в NextDate и DescribeRepeat.
+// Параметры:
+// limits - новые границы.
+// Возвращает: ошибку, если границы некорректны (см. RepeatLimits.Validate); в этом случае действующие границы не меняются.
+func SetRepeatLimits(limits RepeatLimits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	repeatLimits.Store(&limits)
+	return nil
+}
+
+// CurrentRepeatLimits возвращает действующие границы аргументов правил повторения.
+func CurrentRepeatLimits() RepeatLimits {
+	if limits := repeatLimits.Load(); limits != nil {
+		return *limits
+	}
+	return DefaultRepeatLimits
+}
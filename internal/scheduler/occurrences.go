@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"errors"
+	"time"
+)
+
+// Occurrences возвращает даты повторений задачи, попадающие в диапазон [from, to] включительно.
+// Первым повторением считается сама дата задачи `dstart`; следующие вычисляются по правилу repeat.
+// Для задачи без правила повторения возвращается только `dstart` (если она попадает в диапазон).
+// Параметры:
+// dstart - дата задачи в формате DateFormat;
+// repeat - правило повторения (может быть пустым);
+// from, to - границы диапазона;
+// limit - максимальное количество возвращаемых дат (защита от слишком длинной развёртки).
+// Возвращает:
+// слайс дат в формате DateFormat в порядке возрастания и ошибку при некорректных входных данных.
+func Occurrences(dstart, repeat string, from, to time.Time, limit int) ([]string, error) {
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than 0")
+	}
+
+	date, err := time.Parse(DateFormat, dstart)
+	if err != nil {
+		return nil, err
+	}
+
+	lower := from.Format(DateFormat)
+	upper := to.Format(DateFormat)
+
+	// Задача без повторения встречается один раз
+	if repeat == "" {
+		if dstart >= lower && dstart <= upper {
+			return []string{dstart}, nil
+		}
+		return nil, nil
+	}
+
+	current := dstart
+	// Если задача начинается раньше диапазона, сразу переходим к первому повторению не раньше from
+	if current < lower {
+		current, err = NextDate(from.AddDate(0, 0, -1), dstart, repeat)
+		if err != nil {
+			return nil, err
+		}
+		if date, err = time.Parse(DateFormat, current); err != nil {
+			return nil, err
+		}
+	}
+
+	var dates []string
+	for current <= upper && len(dates) < limit {
+		dates = append(dates, current)
+
+		// Следующее повторение строго после текущего
+		current, err = NextDate(date, current, repeat)
+		if err != nil {
+			return nil, err
+		}
+		if date, err = time.Parse(DateFormat, current); err != nil {
+			return nil, err
+		}
+	}
+
+	return dates, nil
+}
+
+// maxOccurrenceIndex - максимальный номер повторения, вычисляемый OccurrenceIndex
+// (защита от слишком длинной развёртки для старых задач с частым повторением).
+const maxOccurrenceIndex = 10000
+
+// OccurrenceIndex возвращает порядковый номер повторения задачи с датой `date` в серии, начатой в `dstart`
+// (например, 5 - пятое повторение еженедельной задачи). Номер равен количеству повторений
+// по правилу repeat раньше `date`, плюс один; для первого повторения и задач без правила - 1.
+// Параметры:
+// dstart - дата начала серии в формате DateFormat;
+// repeat - правило повторения (может быть пустым);
+// date - текущая дата задачи в формате DateFormat.
+// Возвращает:
+// номер повторения (не больше maxOccurrenceIndex) и ошибку при некорректных входных данных.
+func OccurrenceIndex(dstart, repeat, date string) (int, error) {
+	if repeat == "" || date <= dstart {
+		return 1, nil
+	}
+
+	from, err := time.Parse(DateFormat, dstart)
+	if err != nil {
+		return 0, err
+	}
+	to, err := time.Parse(DateFormat, date)
+	if err != nil {
+		return 0, err
+	}
+
+	// Повторения строго раньше текущей даты задачи
+	dates, err := Occurrences(dstart, repeat, from, to.AddDate(0, 0, -1), maxOccurrenceIndex-1)
+	if err != nil {
+		return 0, err
+	}
+	return len(dates) + 1, nil
+}
@@ -0,0 +1,23 @@
+package scheduler
+
+import "time"
+
+// DaysOverdue вычисляет, на сколько дней просрочена задача с датой `date` относительно `now`.
+// Сравниваются календарные даты: "сегодня" определяется в часовом поясе `now`.
+// Параметры:
+// date - дата задачи в формате DateFormat;
+// now - текущий момент.
+// Возвращает: количество дней просрочки (0 для сегодняшних и будущих задач) и ошибку разбора даты.
+func DaysOverdue(date string, now time.Time) (int, error) {
+	due, err := time.Parse(DateFormat, date)
+	if err != nil {
+		return 0, err
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	days := int(today.Sub(due).Hours() / 24)
+	if days < 0 {
+		return 0, nil
+	}
+	return days, nil
+}
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoPrevDate - у задачи нет повторений до указанной даты (стартовая дата ещё не наступила).
+var ErrNoPrevDate = errors.New("no occurrence on or before the given date")
+
+// maxPrevDateSteps - максимальное количество повторений, перебираемых при поиске предыдущего
+// (защита от бесконечного перебора для очень старых задач с частым повторением).
+const maxPrevDateSteps = 100000
+
+// PrevDate возвращает последнее повторение задачи не позже `now` (календарная дата в часовом поясе `now`).
+// Как и в Occurrences, первым повторением считается сама стартовая дата `dstart`.
+// Параметры:
+// now - текущий момент;
+// dstart - стартовая дата задачи в формате DateFormat;
+// repeat - правило повторения (может быть пустым - тогда единственное повторение - dstart).
+// Возвращает:
+// дату в формате DateFormat и ошибку (ErrNoPrevDate, если `now` раньше стартовой даты).
+func PrevDate(now time.Time, dstart string, repeat string) (string, error) {
+	current, err := time.Parse(DateFormat, dstart)
+	if err != nil {
+		return "", err
+	}
+
+	today := now.Format(DateFormat)
+	if dstart > today {
+		return "", ErrNoPrevDate
+	}
+	if repeat == "" {
+		return dstart, nil
+	}
+
+	prev := dstart
+	for i := 0; i < maxPrevDateSteps; i++ {
+		// Следующее повторение строго после текущего
+		next, err := NextDate(current, prev, repeat)
+		if err != nil {
+			return "", err
+		}
+		if next > today {
+			return prev, nil
+		}
+		prev = next
+		if current, err = time.Parse(DateFormat, next); err != nil {
+			return "", err
+		}
+	}
+	return "", errors.New("too many occurrences before the given date")
+}
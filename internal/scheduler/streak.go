@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"errors"
+	"time"
+)
+
+// Completion описывает выполненное повторение задачи.
+// Scheduled - плановая дата повторения, Completed - фактическая дата выполнения (обе в формате DateFormat).
+type Completion struct {
+	Scheduled string
+	Completed string
+}
+
+// Streaks вычисляет текущую и максимальную серии выполнений повторяющейся задачи в срок.
+// Выполнение считается своевременным, если оно сделано не позже плановой даты.
+// Серия прерывается опозданием или пропуском повторения (плановая дата не следует за предыдущей по правилу).
+// Параметры:
+// repeat - правило повторения задачи;
+// completions - выполненные повторения в хронологическом порядке;
+// due - текущая плановая дата задачи (DateFormat);
+// now - текущая дата (если due уже прошла, текущая серия считается прерванной).
+// Возвращает:
+// текущую серию, максимальную серию и ошибку (если правило повторения некорректно).
+func Streaks(repeat string, completions []Completion, due string, now time.Time) (int, int, error) {
+	if repeat == "" {
+		return 0, 0, errors.New("repeat rule is missing")
+	}
+
+	var current, longest int
+	prev := ""
+
+	for _, c := range completions {
+		// Опоздание прерывает серию
+		if c.Completed > c.Scheduled {
+			current = 0
+			prev = c.Scheduled
+			continue
+		}
+
+		// Если серия продолжается, плановая дата должна быть следующим повторением после предыдущей
+		if current > 0 {
+			prevDate, err := time.Parse(DateFormat, prev)
+			if err != nil {
+				return 0, 0, err
+			}
+			expected, err := NextDate(prevDate, prev, repeat)
+			if err != nil {
+				return 0, 0, err
+			}
+			if c.Scheduled != expected {
+				current = 0
+			}
+		}
+
+		current++
+		if current > longest {
+			longest = current
+		}
+		prev = c.Scheduled
+	}
+
+	// Если очередное повторение уже просрочено, текущая серия прервана
+	if due != "" && due < now.Format(DateFormat) {
+		current = 0
+	}
+
+	return current, longest, nil
+}
@@ -0,0 +1,23 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// NextWeekday возвращает ближайшую дату строго после сегодняшнего дня, приходящуюся на день недели `weekday`.
+// Дата вычисляется по правилу "w" (см. NextDate); "сегодня" - календарная дата `now` в её часовом поясе.
+// Параметры:
+// now - текущий момент;
+// weekday - день недели от 1 (понедельник) до 7 (воскресенье).
+// Возвращает:
+// дату в формате DateFormat и ошибку, если день недели вне диапазона.
+func NextWeekday(now time.Time, weekday int) (string, error) {
+	if weekday < 1 || weekday > 7 {
+		return "", fmt.Errorf("weekday must be in range [1, 7]: got %d", weekday)
+	}
+	// NextDate сравнивает даты в UTC, поэтому переводим календарную дату `now` в полночь UTC
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	return NextDate(today, today.Format(DateFormat), "w "+strconv.Itoa(weekday))
+}
@@ -1,17 +1,28 @@
 package server
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"go-task-manager-final_project/config"
 	"go-task-manager-final_project/internal/api/handlers"
+	"go-task-manager-final_project/internal/api/middleware"
+	"go-task-manager-final_project/internal/scheduler"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"path"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-chi/chi/v5"
+	"golang.org/x/crypto/bcrypt"
 )
 
 const (
@@ -19,8 +30,21 @@ const (
 	defaultStaticDir = "./web" // Директория со статическими файлами по умолчанию
 	minPort          = 1       // Минимально допустимый номер порта
 	maxPort          = 65535   // Максимально допустимый номер порта
+
+	defaultMinPasswordLength = 8 // Минимальная длина пароля по умолчанию
+
+	defaultShutdownTimeout = 10 * time.Second // Время на завершение активных запросов при остановке по умолчанию
+
+	staticPrefix        = "/static/"                            // Префикс путей статики с версией сборки
+	immutableCacheValue = "public, max-age=31536000, immutable" // Cache-Control для статики с версией в пути
+	indexFile           = "index.html"                          // Страница приложения, которая не кэшируется
 )
 
+// Version - версия сборки, задаётся при компиляции:
+// go build -ldflags "-X go-task-manager-final_project/internal/server.Version=1.2.3".
+// Используется в пути статики /static/<Version>/..., чтобы после обновления браузер запрашивал новые файлы.
+var Version = "dev"
+
 // GetPort возвращает номер порта из переменной окружения TODO_PORT или значение по умолчанию.
 // Проверяет корректность формата и диапазона значения порта.
 // Возвращает:
@@ -46,12 +70,94 @@ func GetPort() (int, error) {
 	return port, nil
 }
 
+// GetMinPasswordLength возвращает минимальную длину пароля из переменной окружения TODO_MIN_PASSWORD_LENGTH
+// или значение по умолчанию (defaultMinPasswordLength).
+// Возвращает:
+// - int: минимальная длина пароля;
+// - error: ошибка, если значение не является неотрицательным целым числом.
+func GetMinPasswordLength() (int, error) {
+	if config.MinPasswordLength == "" {
+		return defaultMinPasswordLength, nil
+	}
+	length, err := strconv.Atoi(config.MinPasswordLength)
+	if err != nil || length < 0 {
+		return 0, fmt.Errorf("invalid minimum password length: %s", config.MinPasswordLength)
+	}
+	return length, nil
+}
+
+// ValidatePassword проверяет, что мастер-пароль из TODO_PASSWORD не короче минимальной длины,
+// а хэш из TODO_PASSWORD_HASH (если задан) является корректным bcrypt-хэшем.
+// Пустой пароль допустим: в этом случае аутентификация отключена.
+// Длина считается в символах, а не в байтах; для хэша длина исходного пароля неизвестна и не проверяется.
+// Возвращает:
+// - error: ошибка, если пароль слишком короткий, хэш некорректен или минимальная длина задана некорректно.
+func ValidatePassword() error {
+	minLength, err := GetMinPasswordLength()
+	if err != nil {
+		return err
+	}
+	switch config.PasswordMode() {
+	case config.PasswordModeNone:
+		return nil
+	case config.PasswordModeBcrypt:
+		if _, err := bcrypt.Cost([]byte(config.PasswordHash)); err != nil {
+			return fmt.Errorf("TODO_PASSWORD_HASH is not a valid bcrypt hash: %w", err)
+		}
+		return nil
+	}
+	if length := utf8.RuneCountInString(config.Password); length < minLength {
+		return fmt.Errorf("TODO_PASSWORD is too short: %d characters, at least %d required (see TODO_MIN_PASSWORD_LENGTH)", length, minLength)
+	}
+	return nil
+}
+
+// ConfigureRepeatLimits применяет границы числовых аргументов правил повторения
+// из переменных окружения TODO_REPEAT_MAX_DAYS (интервал "d") и TODO_REPEAT_MAX_MONTH_DAY (день месяца "m").
+// Незаданные значения берутся из scheduler.DefaultRepeatLimits; границы можно только сузить.
+// Возвращает:
+// - error: ошибка, если значение не является целым числом или выходит за допустимый диапазон.
+func ConfigureRepeatLimits() error {
+	limits := scheduler.DefaultRepeatLimits
+
+	if config.RepeatMaxDays != "" {
+		value, err := strconv.Atoi(config.RepeatMaxDays)
+		if err != nil {
+			return fmt.Errorf("invalid TODO_REPEAT_MAX_DAYS: %s", config.RepeatMaxDays)
+		}
+		limits.MaxDays = value
+	}
+	if config.RepeatMaxMonthDay != "" {
+		value, err := strconv.Atoi(config.RepeatMaxMonthDay)
+		if err != nil {
+			return fmt.Errorf("invalid TODO_REPEAT_MAX_MONTH_DAY: %s", config.RepeatMaxMonthDay)
+		}
+		limits.MaxMonthDay = value
+	}
+
+	return scheduler.SetRepeatLimits(limits)
+}
+
+// ConfigureLeapDayPolicy применяет правило "y" для задач от 29 февраля
+// из переменной окружения TODO_LEAP_DAY_POLICY ("feb28" - по умолчанию, или "leap").
+// Возвращает:
+// - error: ошибка, если значение не поддерживается.
+func ConfigureLeapDayPolicy() error {
+	policy, err := scheduler.ParseLeapDayPolicy(config.LeapDayPolicy)
+	if err != nil {
+		return fmt.Errorf("invalid TODO_LEAP_DAY_POLICY: %w", err)
+	}
+	scheduler.SetLeapDayPolicy(policy)
+	return nil
+}
+
 // GetStaticDir возвращает путь к директории со статическими файлами.
-// Берёт значение из переменной окружения TODO_STATIC_DIR, если она задана.
+// Берёт значение из переменной окружения TODO_STATIC_DIR, если она задана
+// (относительный путь разрешается относительно TODO_DATA_DIR, см. config.ResolvePath).
 // Иначе использует значение по умолчанию (defaultStaticDir).
 // Возвращает: строку - путь к директории со статическими файлами.
 func GetStaticDir() (string, error) {
-	dir := os.Getenv("TODO_STATIC_DIR")
+	dir := config.ResolvePath(os.Getenv("TODO_STATIC_DIR"))
 	if dir == "" {
 		// Если переменная окружения не задана, используем директорию по умолчанию
 		dir = defaultStaticDir
@@ -80,33 +186,107 @@ func SetupStaticFileRouting(r *chi.Mux) error {
 	// Создаём файловый сервер для статических файлов
 	fs := http.FileServer(http.Dir(staticDir))
 
+	// Файлы под /static/<версия>/ отдаются из той же директории и кэшируются надолго:
+	// при смене версии меняется и путь, поэтому устаревшие копии не используются
+	versionPrefix := staticPrefix + Version + "/"
+	r.Handle(versionPrefix+"*", staticCacheHeaders(immutableCacheValue, http.StripPrefix(versionPrefix, fs)))
+
 	// Настраиваем роутинг: все запросы перенаправляются на статические файлы
 	// (префикс "/" удаляется из пути - это позволяет корректно обрабатывать запросы к файлам)
-	r.Handle("/*", http.StripPrefix("/", fs))
-	log.Printf("Роутинг настроен для статических файлов из %s", staticDir)
+	r.Handle("/*", staticCacheHeaders("", http.StripPrefix("/", fs)))
+	log.Printf("Роутинг настроен для статических файлов из %s (версия %s)", staticDir, Version)
 
 	return nil
 }
 
-// StartServer запускает HTTP-сервер с заданной конфигурацией.
-// Настраивает роутер, подключает обработчики, устанавливает таймауты и запускает сервер.
+// staticCacheHeaders добавляет к ответам со статикой заголовок Cache-Control.
+// Страница index.html (в том числе корень каталога) всегда отдаётся с no-cache,
+// чтобы браузер получал ссылки на актуальную версию статики.
+// Параметры:
+// - cacheControl string: значение Cache-Control для остальных файлов (пустое - заголовок не добавляется);
+// - next http.Handler: файловый сервер.
+// Возвращает: http.Handler с установкой заголовка.
+func staticCacheHeaders(cacheControl string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/") || path.Base(r.URL.Path) == indexFile {
+			w.Header().Set("Cache-Control", "no-cache")
+		} else if cacheControl != "" {
+			w.Header().Set("Cache-Control", cacheControl)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// GetContentSecurityPolicy возвращает значение Content-Security-Policy из переменной окружения TODO_CSP.
+// Если переменная не задана, используется политика по умолчанию для встроенного веб-интерфейса;
+// значение "off" отключает заголовок.
+func GetContentSecurityPolicy() string {
+	switch config.ContentSecurityPolicy {
+	case "":
+		return middleware.DefaultContentSecurityPolicy
+	case "off":
+		return ""
+	default:
+		return config.ContentSecurityPolicy
+	}
+}
+
+// NewRouter создаёт роутер chi со статическими файлами и API-обработчиками.
+// Заголовки безопасности добавляются ко всем ответам (и API, и статике),
+// а паника в любом обработчике перехватывается middleware.Recoverer.
 // Параметры:
 // - db *sql.DB: подключение к базе данных, передаваемое обработчикам.
 // Возвращает:
-// - error: ошибка при конфигурации или запуске сервера (включая проблемы с портом, статикой и тд.).
-func StartServer(db *sql.DB) error {
+// - *chi.Mux: настроенный роутер;
+// - error: ошибка при настройке статических файлов.
+func NewRouter(db *sql.DB) (*chi.Mux, error) {
 	// Создаём новый роутер chi
 	router := chi.NewRouter()
 
+	// Middleware подключается до регистрации маршрутов.
+	// Recoverer - первым, чтобы паника в любом обработчике (API или статики) давала ответ 500, а не остановку сервера.
+	router.Use(middleware.Recoverer)
+	router.Use(middleware.SecurityHeaders(GetContentSecurityPolicy()))
+
 	// Настраиваем обработку статических файлов
 	err := SetupStaticFileRouting(router)
 	if err != nil {
-		return fmt.Errorf("failed to setup static file routing: %w", err)
+		return nil, fmt.Errorf("failed to setup static file routing: %w", err)
 	}
 
 	// Регистрируем API-обработчики, передавая роутер и подключение к БД
 	handlers.Init(router, db)
 
+	return router, nil
+}
+
+// StartServer запускает HTTP-сервер с заданной конфигурацией.
+// Настраивает роутер, подключает обработчики, устанавливает таймауты и запускает сервер.
+// Блокируется до сигнала SIGINT/SIGTERM, после которого дожидается завершения активных запросов (см. Serve).
+// Параметры:
+// - db *sql.DB: подключение к базе данных, передаваемое обработчикам.
+// Возвращает:
+// - error: ошибка при конфигурации, запуске или остановке сервера (включая проблемы с портом, статикой и тд.).
+func StartServer(db *sql.DB) error {
+	// Не запускаемся со слишком коротким мастер-паролем
+	if err := ValidatePassword(); err != nil {
+		return fmt.Errorf("invalid password configuration: %w", err)
+	}
+
+	// Не запускаемся с некорректными границами аргументов правил повторения
+	if err := ConfigureRepeatLimits(); err != nil {
+		return fmt.Errorf("invalid repeat limits configuration: %w", err)
+	}
+	if err := ConfigureLeapDayPolicy(); err != nil {
+		return fmt.Errorf("invalid repeat configuration: %w", err)
+	}
+
+	// Создаём роутер со статикой и API-обработчиками
+	router, err := NewRouter(db)
+	if err != nil {
+		return err
+	}
+
 	// Получаем номер порта для запуска сервера
 	port, err := GetPort()
 	if err != nil {
@@ -125,12 +305,64 @@ func StartServer(db *sql.DB) error {
 		IdleTimeout:  120 * time.Second, // Таймаут для неактивных соединений
 	}
 
+	// Останавливаемся по Ctrl+C (SIGINT) или SIGTERM, дав активным запросам завершиться
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		// Логируем ошибку запуска и возвращаем ошибку запуска сервера
+		log.Printf("Ошибка при запуске сервера: %v", err)
+		return fmt.Errorf("server failed to listen: %w", err)
+	}
+
 	// Логируем запуск сервера
 	log.Printf("Сервер запущен на http://localhost:%d", port)
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		// Логируем ошибку запуска и возвращаем ошибку запуска сервера
+	return Serve(ctx, server, listener, ShutdownTimeout())
+}
+
+// ShutdownTimeout возвращает время на завершение активных запросов при остановке сервера
+// из переменной окружения TODO_SHUTDOWN_TIMEOUT (длительность Go, например "30s").
+// Если значение не задано или некорректно (не длительность Go или не положительное), используется defaultShutdownTimeout.
+func ShutdownTimeout() time.Duration {
+	timeout, err := time.ParseDuration(config.ShutdownTimeout)
+	if err != nil || timeout <= 0 {
+		return defaultShutdownTimeout
+	}
+	return timeout
+}
+
+// Serve обслуживает запросы на listener до отмены ctx, после чего корректно останавливает сервер:
+// новые соединения больше не принимаются, а начатые запросы дорабатывают не дольше timeout.
+// Параметры:
+// ctx - контекст, отмена которого запускает остановку (например, по сигналу);
+// server - HTTP-сервер;
+// listener - слушающий сокет;
+// timeout - максимальное время ожидания завершения активных запросов.
+// Возвращает: ошибку обслуживания или остановки (nil при штатной остановке).
+func Serve(ctx context.Context, server *http.Server, listener net.Listener, timeout time.Duration) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		// Сервер остановился сам, без сигнала
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
 		log.Printf("Ошибка при запуске сервера: %v", err)
-		return fmt.Errorf("server failed to listen and serve: %w", err)
+		return fmt.Errorf("server failed to serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	log.Println("Остановка сервера: ожидаем завершения активных запросов")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("server shutdown failed: %w", err)
 	}
+	log.Println("Сервер остановлен")
 	return nil
 }
@@ -6,6 +6,8 @@ import (
 	"go-task-manager-final_project/internal/server"
 	"log"
 	"os"
+	"strconv"
+	"time"
 )
 
 // В main инициализируем соединение с базой данных, обеспечиваем его корректное закрытие и запускаем HTTP-сервер для обработки запросов.
@@ -17,20 +19,73 @@ func main() {
 		os.Exit(1) // Критическая ошибка — без конфига работа невозможна
 	}
 
+	// Режим транзакций задаётся до открытия БД: при TODO_TX_IMMEDIATE=true транзакции начинаются с BEGIN IMMEDIATE
+	txImmediate, _ := strconv.ParseBool(config.TxImmediate)
+	db.SetTxImmediate(txImmediate)
+
+	// Относительный путь из TODO_DBFILE разрешается относительно TODO_DATA_DIR
+	dbFile := config.ResolvePath(config.DatabaseURL)
+
+	// При TODO_BACKUP_ON_START=true копируем файл БД до его открытия и обновления схемы
+	if backup, _ := strconv.ParseBool(config.BackupOnStart); backup {
+		keep := db.DefaultBackupKeep
+		if config.BackupKeep != "" {
+			var err error
+			if keep, err = strconv.Atoi(config.BackupKeep); err != nil {
+				log.Printf("invalid TODO_BACKUP_KEEP: %s", config.BackupKeep)
+				os.Exit(1)
+			}
+		}
+		path, err := db.BackupFile(dbFile, keep, time.Now())
+		if err != nil {
+			log.Printf("failed to back up database: %v", err)
+			os.Exit(1)
+		}
+		if path != "" {
+			log.Printf("Создана резервная копия БД: %s", path)
+		}
+	}
+
 	// Открываем соединения с БД и, при необходимости, создаем схему
-	db, err := db.Init(config.DatabaseURL)
+	database, err := db.Init(dbFile)
 	if err != nil {
 		log.Printf("failed to initialize database: %v", err)
+		os.Exit(1)
 	}
 	// Обеспечиваем закрытие соединения с БД при завершении работы программы (даже в случае паники или ошибки).
 	defer func() {
-		if closeErr := db.Close(); closeErr != nil {
+		if closeErr := database.Close(); closeErr != nil {
 			log.Printf("failed to close database connection: %v", closeErr)
 		}
 	}()
 
+	// Исправляем задачи с пустой датой (устанавливаем сегодняшнюю)
+	if fixed, err := db.FixEmptyDates(database); err != nil {
+		log.Printf("failed to fix empty task dates: %v", err)
+	} else if fixed > 0 {
+		log.Printf("Задачам с пустой датой установлена сегодняшняя дата: %d", fixed)
+	}
+
+	// Включаем (или отключаем) уникальность заголовков задач в пределах даты по флагу TODO_UNIQUE_TITLE_PER_DATE
+	uniqueTitles, _ := strconv.ParseBool(config.UniqueTitlePerDate)
+	if err = db.SetUniqueTitlePerDate(database, uniqueTitles); err != nil {
+		log.Printf("failed to apply unique title setting: %v", err)
+		database.Close()
+		os.Exit(1)
+	}
+
+	// При TODO_SELFTEST=true проверяем запись, чтение и удаление задачи до начала обслуживания запросов
+	if selfTest, _ := strconv.ParseBool(config.SelfTest); selfTest {
+		if err = db.SelfTest(database); err != nil {
+			log.Printf("startup self-test failed: %v", err)
+			database.Close()
+			os.Exit(1)
+		}
+		log.Println("Самопроверка БД пройдена")
+	}
+
 	// Запускаем сервер
-	err = server.StartServer(db)
+	err = server.StartServer(database)
 	if err != nil {
 		log.Printf("failed to start server: %v", err)
 		return
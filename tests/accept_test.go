@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptNegotiation(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	// Неподдерживаемый тип - 406 со списком поддерживаемых типов
+	resp, body := doRequestWithHeaders(t, srv, http.MethodGet, "/api/tasks", nil,
+		map[string]string{"Accept": "application/yaml"})
+	assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+	var m map[string]any
+	require.NoError(t, json.Unmarshal(body, &m))
+	assert.Equal(t, []any{"application/json"}, m["supported"])
+
+	// Шаблон */* - обычный JSON-ответ
+	for _, accept := range []string{"*/*", "application/*", "text/html, application/json;q=0.8", ""} {
+		resp, body = doRequestWithHeaders(t, srv, http.MethodGet, "/api/tasks", nil,
+			map[string]string{"Accept": accept})
+		assert.Equal(t, http.StatusOK, resp.StatusCode, accept)
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"), accept)
+		assert.True(t, json.Valid(body), accept)
+	}
+
+	// Явный отказ от JSON через q=0
+	resp, _ = doRequestWithHeaders(t, srv, http.MethodGet, "/api/tasks", nil,
+		map[string]string{"Accept": "application/json;q=0"})
+	assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+
+	// nextdate отдаёт результат в текстовом виде
+	resp, _ = doRequestWithHeaders(t, srv, http.MethodGet, "/api/nextdate?now=20240126&date=20240113&repeat=d+7", nil,
+		map[string]string{"Accept": "text/plain"})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
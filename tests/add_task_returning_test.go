@@ -0,0 +1,42 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddTaskReturnsTask(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	// Дата в прошлом с правилом повторения корректируется при создании
+	code, body := doRequest(t, srv, http.MethodPost, "/api/task", map[string]any{
+		"date":   time.Now().AddDate(0, 0, -1).Format(`20060102`),
+		"title":  "Полить цветы",
+		"repeat": "d 3",
+	})
+	require.Equal(t, http.StatusCreated, code)
+
+	var resp struct {
+		ID   json.Number       `json:"id"`
+		Task map[string]string `json:"task"`
+	}
+	require.NoError(t, json.Unmarshal(body, &resp))
+
+	assert.NotEmpty(t, resp.ID)
+	assert.Equal(t, resp.ID.String(), resp.Task["id"])
+	assert.Equal(t, "Полить цветы", resp.Task["title"])
+	assert.Equal(t, time.Now().AddDate(0, 0, 2).Format(`20060102`), resp.Task["date"])
+
+	// Возвращённая задача совпадает с сохранённой
+	code, body = doRequest(t, srv, http.MethodGet, fmt.Sprintf("/api/task?id=%s", resp.ID), nil)
+	require.Equal(t, http.StatusOK, code)
+	var stored map[string]string
+	require.NoError(t, json.Unmarshal(body, &stored))
+	assert.Equal(t, resp.Task, stored)
+}
@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgenda(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	// 20240101 - понедельник
+	insertTask(t, database, "20240101", "Тренировка", "", "w 1,3,5")
+	insertTask(t, database, "20240105", "Разовая", "", "")
+	insertTask(t, database, "20240120", "Вне диапазона", "", "")
+
+	code, body := doRequest(t, srv, http.MethodGet, "/api/agenda?from=20240101&to=20240114", nil)
+	require.Equal(t, http.StatusOK, code)
+
+	var resp struct {
+		Agenda []map[string]string `json:"agenda"`
+	}
+	require.NoError(t, json.Unmarshal(body, &resp))
+
+	var got []string
+	for _, entry := range resp.Agenda {
+		got = append(got, entry["date"]+" "+entry["title"])
+	}
+	assert.Equal(t, []string{
+		"20240101 Тренировка",
+		"20240103 Тренировка",
+		"20240105 Тренировка",
+		"20240105 Разовая",
+		"20240108 Тренировка",
+		"20240110 Тренировка",
+		"20240112 Тренировка",
+	}, got)
+
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/agenda?from=20240114&to=20240101", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgendaText(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	now := time.Now()
+	today := now.Format(`20060102`)
+	insertTask(t, database, today, "Позвонить маме", "", "")
+	insertTask(t, database, now.AddDate(0, 0, -7).Format(`20060102`), "Уборка", "", "d 7")
+	insertTask(t, database, now.AddDate(0, 0, 3).Format(`20060102`), "Не сегодня", "", "")
+
+	resp, body := doRequestWithHeaders(t, srv, http.MethodGet, "/api/agenda.txt", nil,
+		map[string]string{"Accept": "text/plain"})
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.True(t, strings.HasPrefix(resp.Header.Get("Content-Type"), "text/plain"))
+
+	// Одна строка на задачу: дата, заголовок и правило повторения
+	lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+	assert.ElementsMatch(t, []string{
+		today + "  Позвонить маме",
+		today + "  Уборка  [d 7]",
+	}, lines)
+
+	// Явно заданный день без задач - пустой ответ
+	code, body := doRequest(t, srv, http.MethodGet, "/api/agenda.txt?date="+now.AddDate(0, 0, 2).Format(`20060102`), nil)
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, string(body))
+
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/agenda.txt?date=tomorrow", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
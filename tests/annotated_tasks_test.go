@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotatedTasksDaysOverdue(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	now := time.Now()
+	insertTask(t, database, now.AddDate(0, 0, -3).Format(`20060102`), "Просрочена на 3 дня", "", "")
+	insertTask(t, database, now.AddDate(0, 0, -40).Format(`20060102`), "Просрочена на 40 дней", "", "")
+	insertTask(t, database, now.Format(`20060102`), "Сегодня", "", "")
+	insertTask(t, database, now.AddDate(0, 0, 5).Format(`20060102`), "В будущем", "", "")
+
+	code, body := doRequest(t, srv, http.MethodGet, "/api/tasks/annotated", nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+
+	var resp struct {
+		Tasks []struct {
+			Title       string `json:"title"`
+			DaysOverdue int    `json:"days_overdue"`
+		} `json:"tasks"`
+	}
+	require.NoError(t, json.Unmarshal(body, &resp))
+
+	got := map[string]int{}
+	for _, task := range resp.Tasks {
+		got[task.Title] = task.DaysOverdue
+	}
+	assert.Equal(t, map[string]int{
+		"Просрочена на 3 дня":   3,
+		"Просрочена на 40 дней": 40,
+		"Сегодня":               0,
+		"В будущем":             0,
+	}, got)
+}
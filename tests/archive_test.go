@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func TestArchiveTask(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	id := insertTask(t, database, "20240126", "Проект А", "", "")
+	insertTask(t, database, "20240127", "Проект Б", "", "")
+
+	titles := func(path string) []string {
+		code, body := doRequest(t, srv, http.MethodGet, path, nil)
+		require.Equal(t, http.StatusOK, code)
+		var m map[string][]map[string]any
+		require.NoError(t, json.Unmarshal(body, &m))
+		res := []string{}
+		for _, task := range m["tasks"] {
+			res = append(res, task["title"].(string))
+		}
+		return res
+	}
+
+	code, _ := doRequest(t, srv, http.MethodPost, "/api/task/archive?id="+id, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	assert.Equal(t, []string{"Проект Б"}, titles("/api/tasks"))
+	assert.ElementsMatch(t, []string{"Проект А", "Проект Б"}, titles("/api/tasks?include=archived"))
+	assert.Equal(t, []string{"Проект А"}, titles("/api/tasks/archived"))
+
+	// Архивная задача по-прежнему доступна по ID
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/task?id="+id, nil)
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task/unarchive?id="+id, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	assert.ElementsMatch(t, []string{"Проект А", "Проект Б"}, titles("/api/tasks"))
+	assert.Empty(t, titles("/api/tasks/archived"))
+
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task/archive?id=987654", nil)
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestArchivedTasksOrder(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	// Задачи добавлены не в порядке дат
+	for _, task := range [][2]string{{"20240128", "Третья"}, {"20240126", "Первая"}, {"20240127", "Вторая"}} {
+		id := insertTask(t, database, task[0], task[1], "", "")
+		code, _ := doRequest(t, srv, http.MethodPost, "/api/task/archive?id="+id, nil)
+		require.Equal(t, http.StatusOK, code)
+	}
+
+	code, body := doRequest(t, srv, http.MethodGet, "/api/tasks/archived", nil)
+	require.Equal(t, http.StatusOK, code)
+	var m map[string][]map[string]any
+	require.NoError(t, json.Unmarshal(body, &m))
+	titles := []string{}
+	for _, task := range m["tasks"] {
+		titles = append(titles, task["title"].(string))
+	}
+	assert.Equal(t, []string{"Первая", "Вторая", "Третья"}, titles)
+}
+
+func TestArchiveColumnAddedToExistingDB(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "old.db")
+
+	// БД со схемой первой версии (без колонки archived)
+	old, err := sql.Open("sqlite", dbFile)
+	require.NoError(t, err)
+	_, err = old.Exec(`CREATE TABLE scheduler (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		date CHAR(8) NOT NULL DEFAULT '',
+		title VARCHAR(255) NOT NULL,
+		comment TEXT,
+		repeat VARCHAR(128)
+	)`)
+	require.NoError(t, err)
+	_, err = old.Exec(`INSERT INTO scheduler (date, title, comment, repeat) VALUES ('20240126', 'Старая задача', '', '')`)
+	require.NoError(t, err)
+	require.NoError(t, old.Close())
+
+	database, err := db.Init(dbFile)
+	require.NoError(t, err)
+	defer database.Close()
+
+	tasks, err := db.GetTasks(database, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.False(t, tasks[0].Archived)
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupOnStart(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "scheduler.db")
+
+	// Файла ещё нет - копировать нечего
+	path, err := db.BackupFile(dbFile, 3, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, path)
+
+	database, err := db.Init(dbFile)
+	require.NoError(t, err)
+	insertTask(t, database, "20240126", "Задача", "", "")
+	require.NoError(t, database.Close())
+
+	// Копия совпадает с файлом БД
+	start := time.Date(2024, 1, 26, 9, 0, 0, 0, time.UTC)
+	path, err = db.BackupFile(dbFile, 3, start)
+	require.NoError(t, err)
+	assert.Equal(t, dbFile+".bak.20240126T090000", path)
+	original, err := os.ReadFile(dbFile)
+	require.NoError(t, err)
+	copied, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, original, copied)
+
+	// Из копии открывается рабочая БД
+	restored, err := db.Init(path)
+	require.NoError(t, err)
+	tasks, err := db.GetTasks(restored, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 1)
+	require.NoError(t, restored.Close())
+
+	// Хранятся только три последние копии
+	for i := 1; i <= 4; i++ {
+		_, err = db.BackupFile(dbFile, 3, start.Add(time.Duration(i)*time.Hour))
+		require.NoError(t, err)
+	}
+	backups, err := filepath.Glob(dbFile + ".bak.*")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		dbFile + ".bak.20240126T110000",
+		dbFile + ".bak.20240126T120000",
+		dbFile + ".bak.20240126T130000",
+	}, backups)
+
+	// БД в памяти пропускается, некорректное количество копий отклоняется
+	path, err = db.BackupFile(":memory:", 3, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, path)
+	_, err = db.BackupFile(dbFile, 0, time.Now())
+	assert.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"go-task-manager-final_project/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBearerToken(t *testing.T) {
+	prevPassword, prevSecret := config.Password, config.JWTSecret
+	t.Cleanup(func() { config.Password, config.JWTSecret = prevPassword, prevSecret })
+	config.Password, config.JWTSecret = "12345678", "test-secret"
+
+	srv, _ := newTestServer(t)
+
+	code, body := doRequest(t, srv, http.MethodPost, "/api/signin", map[string]any{"password": "12345678"})
+	require.Equal(t, http.StatusOK, code, string(body))
+	var signin map[string]string
+	require.NoError(t, json.Unmarshal(body, &signin))
+	token := signin["token"]
+
+	session := func(headers map[string]string) int {
+		resp, _ := doRequestWithHeaders(t, srv, http.MethodGet, "/api/session", nil, headers)
+		return resp.StatusCode
+	}
+
+	// Только заголовок Authorization (схема без учёта регистра)
+	assert.Equal(t, http.StatusOK, session(map[string]string{"Authorization": "Bearer " + token}))
+	assert.Equal(t, http.StatusOK, session(map[string]string{"Authorization": "bearer " + token}))
+	assert.Equal(t, http.StatusUnauthorized, session(map[string]string{"Authorization": "Bearer not-a-jwt"}))
+	assert.Equal(t, http.StatusUnauthorized, session(map[string]string{"Authorization": "Bearer "}))
+
+	// Только cookie
+	assert.Equal(t, http.StatusOK, session(map[string]string{"Cookie": "token=" + token}))
+
+	// Заголовок и cookie: проверяется токен из заголовка
+	assert.Equal(t, http.StatusOK, session(map[string]string{
+		"Authorization": "Bearer " + token,
+		"Cookie":        "token=not-a-jwt",
+	}))
+	assert.Equal(t, http.StatusUnauthorized, session(map[string]string{
+		"Authorization": "Bearer not-a-jwt",
+		"Cookie":        "token=" + token,
+	}))
+
+	// Заголовок с другой схемой не мешает входу по cookie
+	assert.Equal(t, http.StatusOK, session(map[string]string{
+		"Authorization": "Basic dXNlcjpwYXNz",
+		"Cookie":        "token=" + token,
+	}))
+	assert.Equal(t, http.StatusUnauthorized, session(map[string]string{"Authorization": "Basic dXNlcjpwYXNz"}))
+}
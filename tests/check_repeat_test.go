@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRepeat(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	check := func(query string) (bool, string) {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/repeat/check?"+query, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp struct {
+			Matches     bool   `json:"matches"`
+			Explanation string `json:"explanation"`
+		}
+		require.NoError(t, json.Unmarshal(body, &resp))
+		return resp.Matches, resp.Explanation
+	}
+
+	// 29.01.2024 - понедельник, 30.01.2024 - вторник
+	matches, explanation := check("date=20240129&rule=w+1,3,5")
+	assert.True(t, matches)
+	assert.NotEmpty(t, explanation)
+
+	matches, explanation = check("date=20240130&rule=w+1,3,5")
+	assert.False(t, matches)
+	assert.Equal(t, "date is a Tuesday but rule matches only Monday, Wednesday, Friday", explanation)
+
+	// Правило "m": не тот день и не тот месяц
+	matches, explanation = check("date=20240115&rule=m+1,-1")
+	assert.False(t, matches)
+	assert.Equal(t, "date is day 15 of the month but rule matches only day 1, the last day", explanation)
+	matches, _ = check("date=20240131&rule=m+1,-1")
+	assert.True(t, matches)
+	matches, explanation = check("date=20240401&rule=m+1+1,3")
+	assert.False(t, matches)
+	assert.Equal(t, "date is in April but rule matches only January, March", explanation)
+
+	// Правила "d" и "y" отсчитываются от стартовой даты
+	matches, _ = check("date=20240127&rule=d+7&start=20240113")
+	assert.True(t, matches)
+	matches, explanation = check("date=20240125&rule=d+7&start=20240113")
+	assert.False(t, matches)
+	assert.Contains(t, explanation, "every 7 days")
+	assert.Contains(t, explanation, "20240120 and 20240127")
+	matches, _ = check("date=20260126&rule=y&start=20240126")
+	assert.True(t, matches)
+	matches, explanation = check("date=20260127&rule=y&start=20240126")
+	assert.False(t, matches)
+	assert.Equal(t, "date is January 27 but rule repeats every year on January 26", explanation)
+
+	// Правило "h" - дата совпадает, если на неё приходится хотя бы одно повторение от полуночи стартовой даты
+	matches, _ = check("date=20240117&rule=h+48&start=20240113")
+	assert.True(t, matches)
+	matches, explanation = check("date=20240118&rule=h+48&start=20240113")
+	assert.False(t, matches)
+	assert.Contains(t, explanation, "every 48 hours")
+	assert.Contains(t, explanation, "20240117 and 20240119")
+	// 36 часов: 13.01 00:00, 14.01 12:00, 16.01 00:00 - 15.01 пропускается
+	matches, explanation = check("date=20240114&rule=h+36&start=20240113")
+	assert.True(t, matches)
+	assert.Contains(t, explanation, "occurrence at 12:00")
+	matches, _ = check("date=20240115&rule=h+36&start=20240113")
+	assert.False(t, matches)
+	// Интервал короче суток совпадает с каждым днём, включая переход через границу месяца
+	matches, _ = check("date=20240201&rule=h+7&start=20240131")
+	assert.True(t, matches)
+	matches, _ = check("date=20240112&rule=h+7&start=20240113")
+	assert.False(t, matches)
+
+	// Некорректные параметры - 400
+	for _, query := range []string{
+		"date=20240130&rule=h+48",
+		"date=20240130&rule=h+0&start=20240113",
+		"date=20240130&rule=h+9624&start=20240113",
+		"rule=w+1",
+		"date=2024-01-30&rule=w+1",
+		"date=20240130&rule=w+8",
+		"date=20240130&rule=d+7",
+		"date=20240130&rule=x",
+	} {
+		code, _ := doRequest(t, srv, http.MethodGet, "/api/repeat/check?"+query, nil)
+		assert.Equal(t, http.StatusBadRequest, code, query)
+	}
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClearCompleted(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	now := time.Now()
+	past := now.AddDate(0, 0, -5).Format(scheduler.DateFormat)
+	future := now.AddDate(0, 0, 5).Format(scheduler.DateFormat)
+
+	done1 := insertTask(t, database, past, "Выполненная 1", "", "")
+	done2 := insertTask(t, database, future, "Выполненная 2", "", "")
+	overdue := insertTask(t, database, past, "Просроченная", "", "")
+	recurring := insertTask(t, database, past, "Повторяющаяся", "", "d 3")
+	upcoming := insertTask(t, database, future, "Будущая", "", "")
+	markDone := func(id string) {
+		require.NoError(t, db.UpdateStatus(database, id, db.StatusDone))
+	}
+	markDone(done1)
+	markDone(done2)
+
+	preview := func() (int, string) {
+		code, body := doRequest(t, srv, http.MethodPost, "/api/tasks/clear-completed", nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp struct {
+			WouldDelete int    `json:"would_delete"`
+			Confirm     string `json:"confirm"`
+		}
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.NotEmpty(t, resp.Confirm)
+		return resp.WouldDelete, resp.Confirm
+	}
+	exists := func(id string) bool {
+		_, err := db.GetTask(database, id)
+		return err == nil
+	}
+
+	// Предварительный просмотр ничего не удаляет
+	count, token := preview()
+	assert.Equal(t, 2, count)
+	assert.True(t, exists(done1))
+
+	// Неверный токен отклоняется
+	code, _ := doRequest(t, srv, http.MethodPost, "/api/tasks/clear-completed?confirm=wrong", nil)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.True(t, exists(done1))
+
+	// После выполнения ещё одной задачи токен устаревает
+	extra := insertTask(t, database, future, "Выполненная 3", "", "")
+	markDone(extra)
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/tasks/clear-completed?confirm="+token, nil)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.True(t, exists(done1))
+
+	// С актуальным токеном удаляются только выполненные задачи, просроченная невыполненная остаётся
+	count, token = preview()
+	assert.Equal(t, 3, count)
+	code, body := doRequest(t, srv, http.MethodPost, "/api/tasks/clear-completed?confirm="+token, nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+	assert.JSONEq(t, `{"deleted":3}`, string(body))
+
+	assert.False(t, exists(done1))
+	assert.False(t, exists(done2))
+	assert.False(t, exists(extra))
+	assert.True(t, exists(overdue))
+	assert.True(t, exists(recurring))
+	assert.True(t, exists(upcoming))
+
+	// Повторное использование токена после удаления отклоняется
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/tasks/clear-completed?confirm="+token, nil)
+	assert.Equal(t, http.StatusConflict, code)
+}
@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskColor(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	getColor := func(id string) string {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/task?id="+id, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var task map[string]any
+		require.NoError(t, json.Unmarshal(body, &task))
+		color, _ := task["color"].(string)
+		return color
+	}
+
+	code, body := doRequest(t, srv, http.MethodPost, "/api/task", map[string]any{
+		"title": "Цветная задача",
+		"color": "#FF8800",
+	})
+	require.Equal(t, http.StatusCreated, code, string(body))
+	var created struct {
+		ID json.Number `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(body, &created))
+	id := created.ID.String()
+
+	// Цвет сохраняется (в нижнем регистре) и возвращается при чтении
+	assert.Equal(t, "#ff8800", getColor(id))
+
+	// Название из палитры допустимо
+	code, _ = doRequest(t, srv, http.MethodPut, "/api/task", map[string]any{
+		"id": id, "date": "today", "title": "Цветная задача", "color": "green",
+	})
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "green", getColor(id))
+
+	// Некорректный цвет - 422
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task", map[string]any{"title": "Задача", "color": "notacolor"})
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	code, _ = doRequest(t, srv, http.MethodPut, "/api/task", map[string]any{
+		"id": id, "date": "today", "title": "Цветная задача", "color": "#12345",
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	assert.Equal(t, "green", getColor(id))
+}
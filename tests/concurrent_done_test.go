@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvanceDateStale(t *testing.T) {
+	_, database := newTestServer(t)
+
+	today := time.Now().Format(`20060102`)
+	tomorrow := time.Now().AddDate(0, 0, 1).Format(`20060102`)
+	id := insertTask(t, database, today, "Ежедневная", "", "d 1")
+
+	// Первый перенос с прочитанной даты проходит, второй с той же (устаревшей) датой отклоняется
+	require.NoError(t, db.AdvanceDate(database, id, today, tomorrow))
+	assert.ErrorIs(t, db.AdvanceDate(database, id, today, tomorrow), db.ErrStaleTask)
+
+	task, err := db.GetTask(database, id)
+	require.NoError(t, err)
+	assert.Equal(t, tomorrow, task.Date)
+}
+
+func TestConcurrentDone(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	now := time.Now()
+	id := insertTask(t, database, now.Format(`20060102`), "Ежедневная", "", "d 1")
+
+	const requests = 2
+	codes := make([]int, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			codes[i], _ = doRequest(t, srv, http.MethodPost, "/api/task/done?id="+id, nil)
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, code := range codes {
+		if code == http.StatusOK {
+			succeeded++
+		}
+	}
+	require.GreaterOrEqual(t, succeeded, 1)
+
+	// Каждый успешный запрос сдвигает дату ровно на одно повторение: запросы, прочитавшие одну и ту же дату,
+	// не могут сдвинуть её дважды
+	task, err := db.GetTask(database, id)
+	require.NoError(t, err)
+	assert.Equal(t, now.AddDate(0, 0, succeeded).Format(`20060102`), task.Date)
+}
@@ -0,0 +1,25 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddTaskConflict(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	_, err := database.Exec(`CREATE UNIQUE INDEX idx_test_unique_title ON scheduler (title)`)
+	require.NoError(t, err)
+
+	task := map[string]any{"date": "today", "title": "Уникальная задача"}
+
+	code, _ := doRequest(t, srv, http.MethodPost, "/api/task", task)
+	assert.Equal(t, http.StatusCreated, code)
+
+	code, body := doRequest(t, srv, http.MethodPost, "/api/task", task)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Contains(t, string(body), "conflicts")
+}
@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-task-manager-final_project/config"
+	"go-task-manager-final_project/internal/server"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePath(t *testing.T) {
+	prev := config.DataDir
+	t.Cleanup(func() { config.DataDir = prev })
+
+	dataDir := t.TempDir()
+	absolute := filepath.Join(t.TempDir(), "tasks.db")
+
+	// Без базовой директории пути не изменяются
+	config.DataDir = ""
+	assert.Equal(t, "tasks.db", config.ResolvePath("tasks.db"))
+
+	// Относительные пути разрешаются относительно TODO_DATA_DIR
+	config.DataDir = dataDir
+	assert.Equal(t, filepath.Join(dataDir, "tasks.db"), config.ResolvePath("tasks.db"))
+	assert.Equal(t, filepath.Join(dataDir, "web"), config.ResolvePath("./web"))
+
+	// Абсолютные и пустые пути используются как есть
+	assert.Equal(t, absolute, config.ResolvePath(absolute))
+	assert.Empty(t, config.ResolvePath(""))
+}
+
+func TestStaticDirInDataDir(t *testing.T) {
+	prev := config.DataDir
+	t.Cleanup(func() { config.DataDir = prev })
+
+	dataDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dataDir, "public"), 0o755))
+	config.DataDir = dataDir
+
+	t.Setenv("TODO_STATIC_DIR", "public")
+	dir, err := server.GetStaticDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dataDir, "public"), dir)
+
+	// Абсолютный путь не зависит от базовой директории
+	absolute := t.TempDir()
+	t.Setenv("TODO_STATIC_DIR", absolute)
+	dir, err = server.GetStaticDir()
+	require.NoError(t, err)
+	assert.Equal(t, absolute, dir)
+}
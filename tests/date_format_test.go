@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseDateFormat(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	id := insertTask(t, database, "20240115", "Задача", "", "")
+
+	dates := func(path string) []string {
+		code, body := doRequest(t, srv, http.MethodGet, path, nil)
+		require.Equal(t, http.StatusOK, code)
+		var m map[string][]map[string]string
+		require.NoError(t, json.Unmarshal(body, &m))
+		res := []string{}
+		for _, task := range m["tasks"] {
+			res = append(res, task["date"])
+		}
+		return res
+	}
+
+	assert.Equal(t, []string{"20240115"}, dates("/api/tasks"))
+	assert.Equal(t, []string{"20240115"}, dates("/api/tasks?date_format=compact"))
+	assert.Equal(t, []string{"2024-01-15"}, dates("/api/tasks?date_format=iso"))
+
+	code, body := doRequest(t, srv, http.MethodGet, "/api/task?date_format=iso&id="+id, nil)
+	require.Equal(t, http.StatusOK, code)
+	assert.Contains(t, string(body), `"date":"2024-01-15"`)
+
+	// Хранимое значение не меняется
+	var stored string
+	require.NoError(t, database.QueryRow(`SELECT date FROM scheduler WHERE id = ?`, id).Scan(&stored))
+	assert.Equal(t, "20240115", stored)
+
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/tasks?date_format=unix", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
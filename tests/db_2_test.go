@@ -31,7 +31,9 @@ func openDB(t *testing.T) *sqlx.DB {
 	}
 	db, err := sqlx.Connect("sqlite", dbfile)
 	assert.NoError(t, err)
-	return db
+	// Таблица scheduler может содержать колонки, которых нет в структуре Task (например, archived),
+	// поэтому разрешаем sqlx пропускать их при SELECT *
+	return db.Unsafe()
 }
 
 func TestDB(t *testing.T) {
@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"go-task-manager-final_project/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugErrors(t *testing.T) {
+	prev := config.DebugErrors
+	t.Cleanup(func() { config.DebugErrors = prev })
+
+	srv, database := newTestServer(t)
+	id := insertTask(t, database, "20240126", "Задача", "", "")
+
+	// Закрытое соединение с БД вызывает внутреннюю ошибку при изменении задачи
+	require.NoError(t, database.Close())
+
+	failure := func(method, path string, body any) string {
+		code, data := doRequest(t, srv, method, path, body)
+		require.Equal(t, http.StatusInternalServerError, code, string(data))
+		var m map[string]string
+		require.NoError(t, json.Unmarshal(data, &m))
+		return m["error"]
+	}
+	update := map[string]any{"id": id, "date": "20240126", "title": "Изменено"}
+
+	// По умолчанию клиент получает только общее сообщение
+	config.DebugErrors = ""
+	assert.Equal(t, "failed to update task", failure(http.MethodPut, "/api/task", update))
+	assert.Equal(t, "could not delete task", failure(http.MethodDelete, "/api/task?id="+id, nil))
+
+	config.DebugErrors = "false"
+	assert.Equal(t, "failed to update task", failure(http.MethodPut, "/api/task", update))
+
+	// В отладочном режиме сообщение дополняется текстом исходной ошибки
+	config.DebugErrors = "true"
+	assert.Contains(t, failure(http.MethodPut, "/api/task", update), "failed to update task: ")
+	assert.Contains(t, failure(http.MethodDelete, "/api/task?id="+id, nil), "database is closed")
+}
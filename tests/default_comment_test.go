@@ -0,0 +1,41 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"go-task-manager-final_project/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultComment(t *testing.T) {
+	prev := config.DefaultComment
+	config.DefaultComment = "Создано через быстрое добавление"
+	t.Cleanup(func() { config.DefaultComment = prev })
+
+	srv, _ := newTestServer(t)
+
+	create := func(task map[string]any) string {
+		code, body := doRequest(t, srv, http.MethodPost, "/api/task", task)
+		require.Equal(t, http.StatusCreated, code, string(body))
+		var resp struct {
+			Task struct {
+				Comment string `json:"comment"`
+			} `json:"task"`
+		}
+		require.NoError(t, json.Unmarshal(body, &resp))
+		return resp.Task.Comment
+	}
+
+	// Без комментария подставляется комментарий по умолчанию
+	assert.Equal(t, "Создано через быстрое добавление", create(map[string]any{"title": "Быстрая задача"}))
+	// Переданный комментарий не заменяется
+	assert.Equal(t, "Свой комментарий", create(map[string]any{"title": "Задача", "comment": "Свой комментарий"}))
+
+	// Без настройки комментарий остаётся пустым
+	config.DefaultComment = ""
+	assert.Empty(t, create(map[string]any{"title": "Ещё задача"}))
+}
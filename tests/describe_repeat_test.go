@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeRepeat(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	tbl := []struct {
+		rule string
+		lang string
+		want string
+	}{
+		{"d 7", "en", "Every 7 days"},
+		{"d 1", "en", "Every day"},
+		{"d 7", "ru", "Каждые 7 дней"},
+		{"d 3", "ru", "Каждые 3 дня"},
+		{"h 6", "en", "Every 6 hours"},
+		{"h 1", "en", "Every hour"},
+		{"h 6", "ru", "Каждые 6 часов"},
+		{"h 2", "ru", "Каждые 2 часа"},
+		{"h 36", "en", "Every 36 hours"},
+		{"w 1,3,5", "en", "Every week on Monday, Wednesday, Friday"},
+		{"w 1,3,5", "ru", "Каждую неделю: понедельник, среда, пятница"},
+		{"m -1", "en", "Every month on the last day"},
+		{"m -1", "", "Каждый месяц: последний день"},
+		{"m 1,15 1,3", "en", "Every month on day 1, day 15 in January, March"},
+		{"y", "en", "Every year"},
+		{"y", "ru", "Каждый год"},
+	}
+	for _, v := range tbl {
+		code, body := doRequest(t, srv, http.MethodGet,
+			"/api/repeat/describe?rule="+url.QueryEscape(v.rule)+"&lang="+v.lang, nil)
+		require.Equal(t, http.StatusOK, code, v.rule)
+
+		var m map[string]string
+		require.NoError(t, json.Unmarshal(body, &m))
+		assert.Equal(t, v.want, m["description"], v.rule)
+	}
+
+	for _, path := range []string{
+		"/api/repeat/describe?rule=k+34",
+		"/api/repeat/describe?rule=w+8",
+		"/api/repeat/describe?rule=d+7&lang=de",
+	} {
+		code, _ := doRequest(t, srv, http.MethodGet, path, nil)
+		assert.Equal(t, http.StatusBadRequest, code, path)
+	}
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigest(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	day := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.Local)
+
+	insertTask(t, database, "20240315", "Разовая на день сводки", "", "")
+	insertTask(t, database, "20240313", "Через день", "", "d 2")
+	insertTask(t, database, "20240314", "Через три дня", "", "d 3")
+	insertTask(t, database, "20240316", "Завтрашняя", "", "")
+	done := insertTask(t, database, "20240320", "Выполненная", "", "d 5")
+
+	insertCompletion(t, database, done, day, day.Add(10*time.Hour))
+	insertCompletion(t, database, done, day.AddDate(0, 0, -1), day.Add(-time.Hour))
+	// Выполнение удалённой задачи тоже попадает в сводку
+	insertCompletion(t, database, "999", day, day.Add(23*time.Hour))
+
+	code, body := doRequest(t, srv, http.MethodGet, "/api/digest?date=20240315", nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+
+	var resp struct {
+		Date string `json:"date"`
+		Due  []struct {
+			Title string `json:"title"`
+			Date  string `json:"date"`
+		} `json:"due"`
+		Completed []struct {
+			TaskID string `json:"task_id"`
+			Title  string `json:"title"`
+		} `json:"completed"`
+	}
+	require.NoError(t, json.Unmarshal(body, &resp))
+
+	assert.Equal(t, "20240315", resp.Date)
+
+	dueTitles := []string{}
+	for _, task := range resp.Due {
+		dueTitles = append(dueTitles, task.Title)
+		assert.Equal(t, "20240315", task.Date)
+	}
+	assert.ElementsMatch(t, []string{"Разовая на день сводки", "Через день"}, dueTitles)
+
+	require.Len(t, resp.Completed, 2)
+	assert.Equal(t, done, resp.Completed[0].TaskID)
+	assert.Equal(t, "Выполненная", resp.Completed[0].Title)
+	assert.Equal(t, "999", resp.Completed[1].TaskID)
+	assert.Empty(t, resp.Completed[1].Title)
+
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/digest?date=2024-03-15", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
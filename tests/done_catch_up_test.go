@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoneCatchUp(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	now := time.Now()
+	start := now.AddDate(0, 0, -10)
+	dateOf := func(id string) string {
+		task, err := db.GetTask(database, id)
+		require.NoError(t, err)
+		return task.Date
+	}
+
+	// Задача "d 3", просроченная на несколько повторений: по умолчанию сдвигается на один шаг
+	id := insertTask(t, database, start.Format(scheduler.DateFormat), "Полить цветы", "", "d 3")
+	code, body := doRequest(t, srv, http.MethodPost, "/api/task/done?id="+id, nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+	assert.Equal(t, start.AddDate(0, 0, 3).Format(scheduler.DateFormat), dateOf(id))
+
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task/done?id="+id, nil)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, start.AddDate(0, 0, 6).Format(scheduler.DateFormat), dateOf(id))
+
+	// С catch_up=true пропущенные повторения пропускаются: дата - первое повторение после сегодня
+	code, body = doRequest(t, srv, http.MethodPost, "/api/task/done?id="+id+"&catch_up=true", nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+	assert.Equal(t, start.AddDate(0, 0, 12).Format(scheduler.DateFormat), dateOf(id))
+
+	// Для задачи в будущем оба режима дают один шаг
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task/done?id="+id+"&catch_up=true", nil)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, start.AddDate(0, 0, 15).Format(scheduler.DateFormat), dateOf(id))
+
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task/done?id="+id+"&catch_up=maybe", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/scheduler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskDuration(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	today := time.Now().Format(scheduler.DateFormat)
+	tomorrow := time.Now().AddDate(0, 0, 1).Format(scheduler.DateFormat)
+
+	add := func(date string, minutes int) string {
+		code, body := doRequest(t, srv, http.MethodPost, "/api/task", map[string]any{
+			"date": date, "title": "Задача", "duration_minutes": minutes,
+		})
+		require.Equal(t, http.StatusCreated, code, string(body))
+		var created struct {
+			ID json.Number `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(body, &created))
+		return created.ID.String()
+	}
+	getDuration := func(id string) int {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/task?id="+id, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var task struct {
+			DurationMinutes int `json:"duration_minutes"`
+		}
+		require.NoError(t, json.Unmarshal(body, &task))
+		return task.DurationMinutes
+	}
+
+	// Длительность сохраняется и возвращается при чтении
+	first := add(today, 45)
+	assert.Equal(t, 45, getDuration(first))
+	add(today, 30)
+	add(tomorrow, 60)
+	add(today, 0)
+
+	// Длительность изменяется через PUT
+	code, body := doRequest(t, srv, http.MethodPut, "/api/task", map[string]any{
+		"id": first, "date": today, "title": "Задача", "duration_minutes": 90,
+	})
+	require.Equal(t, http.StatusOK, code, string(body))
+	assert.Equal(t, 90, getDuration(first))
+
+	// Значения вне диапазона [0, 1440] отклоняются
+	for _, minutes := range []int{-1, 1441} {
+		code, _ = doRequest(t, srv, http.MethodPost, "/api/task", map[string]any{
+			"date": today, "title": "Задача", "duration_minutes": minutes,
+		})
+		assert.Equal(t, http.StatusUnprocessableEntity, code, minutes)
+	}
+	code, _ = doRequest(t, srv, http.MethodPut, "/api/task", map[string]any{
+		"id": first, "date": today, "title": "Задача", "duration_minutes": 2000,
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	assert.Equal(t, 90, getDuration(first))
+
+	// Сумма за сегодня учитывает только задачи на сегодняшнюю дату
+	code, body = doRequest(t, srv, http.MethodGet, "/api/stats/duration", nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+	assert.JSONEq(t, `{"date":"`+today+`","total_minutes":120,"tasks":3}`, string(body))
+}
@@ -0,0 +1,46 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmptyDateTasks(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	today := time.Now().Format(`20060102`)
+	id := insertTask(t, database, "", "Задача без даты", "", "")
+	insertTask(t, database, "20240126", "Обычная задача", "", "")
+
+	// Список не падает, задача без даты показывается сегодняшней
+	code, body := doRequest(t, srv, http.MethodGet, "/api/tasks?date_format=iso", nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+	var resp map[string][]map[string]any
+	require.NoError(t, json.Unmarshal(body, &resp))
+	require.Len(t, resp["tasks"], 2)
+
+	// Отдельная задача тоже возвращается с сегодняшней датой
+	code, body = doRequest(t, srv, http.MethodGet, "/api/task?id="+id, nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+	assert.Contains(t, string(body), today)
+
+	// Исправление записывает сегодняшнюю дату в БД
+	fixed, err := db.FixEmptyDates(database)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, fixed)
+
+	var stored string
+	require.NoError(t, database.QueryRow(`SELECT date FROM scheduler WHERE id = ?`, id).Scan(&stored))
+	assert.Equal(t, today, stored)
+
+	fixed, err = db.FixEmptyDates(database)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, fixed)
+}
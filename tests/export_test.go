@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportLargeDataset(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	const total = 3000
+	tx, err := database.Begin()
+	require.NoError(t, err)
+	for i := 0; i < total; i++ {
+		_, err = tx.Exec(`INSERT INTO scheduler (date, title, comment, repeat) VALUES (?, ?, ?, ?)`,
+			"20240126", fmt.Sprintf("Задача %d", i), "комментарий", "")
+		require.NoError(t, err)
+	}
+	require.NoError(t, tx.Commit())
+	archived := insertTask(t, database, "20240126", "Архивная", "", "")
+	_, err = database.Exec(`UPDATE scheduler SET archived = 1 WHERE id = ?`, archived)
+	require.NoError(t, err)
+
+	resp, body := doRequestWithHeaders(t, srv, http.MethodGet, "/api/export", nil, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	// Выгрузка - корректный JSON-массив со всеми задачами (включая архивные) по возрастанию ID
+	var tasks []struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+	require.NoError(t, json.Unmarshal(body, &tasks))
+	require.Len(t, tasks, total+1)
+	assert.Equal(t, "Задача 0", tasks[0].Title)
+	assert.Equal(t, archived, tasks[total].ID)
+	for i := 1; i < len(tasks); i++ {
+		prev, _ := strconv.Atoi(tasks[i-1].ID)
+		cur, _ := strconv.Atoi(tasks[i].ID)
+		require.Less(t, prev, cur)
+	}
+
+	// Пустая БД - пустой массив
+	emptySrv, _ := newTestServer(t)
+	_, body = doRequestWithHeaders(t, emptySrv, http.MethodGet, "/api/export", nil, nil)
+	assert.JSONEq(t, `[]`, string(body))
+}
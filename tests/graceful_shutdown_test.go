@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/server"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startSlowServer запускает server.Serve на свободном порту с обработчиком,
+// который отвечает только после закрытия release. Возвращает адрес, канал входа в обработчик,
+// функцию остановки и канал с результатом Serve.
+func startSlowServer(t *testing.T, timeout time.Duration, release <-chan struct{}) (string, <-chan struct{}, context.CancelFunc, <-chan error) {
+	t.Helper()
+
+	entered := make(chan struct{}, 1)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entered <- struct{}{}
+		<-release
+		_, _ = io.WriteString(w, "done")
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	result := make(chan error, 1)
+	go func() {
+		result <- server.Serve(ctx, &http.Server{Handler: handler}, listener, timeout)
+	}()
+	return "http://" + listener.Addr().String(), entered, cancel, result
+}
+
+func TestGracefulShutdownDrainsRequests(t *testing.T) {
+	release := make(chan struct{})
+	addr, entered, stop, result := startSlowServer(t, 5*time.Second, release)
+
+	type response struct {
+		body string
+		err  error
+	}
+	responses := make(chan response, 1)
+	go func() {
+		resp, err := http.Get(addr)
+		if err != nil {
+			responses <- response{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		responses <- response{body: string(body), err: err}
+	}()
+
+	// Останавливаем сервер, пока запрос ещё выполняется
+	<-entered
+	stop()
+
+	// Serve не возвращается, пока запрос не завершён
+	select {
+	case err := <-result:
+		t.Fatalf("Serve returned before the request finished: %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	resp := <-responses
+	require.NoError(t, resp.err)
+	assert.Equal(t, "done", resp.body)
+
+	select {
+	case err := <-result:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after shutdown")
+	}
+
+	// Новые соединения после остановки не принимаются
+	_, err := http.Get(addr)
+	assert.Error(t, err)
+}
+
+func TestGracefulShutdownTimeout(t *testing.T) {
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	addr, entered, stop, result := startSlowServer(t, 50*time.Millisecond, release)
+
+	go func() {
+		if resp, err := http.Get(addr); err == nil {
+			resp.Body.Close()
+		}
+	}()
+
+	<-entered
+	stop()
+
+	// Запрос не успел завершиться за отведённое время - Serve возвращает ошибку остановки
+	select {
+	case err := <-result:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after shutdown timeout")
+	}
+}
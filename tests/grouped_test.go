@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupedTasks(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	now := time.Now()
+	insertTask(t, database, now.AddDate(0, 0, -1).Format(`20060102`), "Вчера", "", "")
+	insertTask(t, database, now.AddDate(0, 0, -10).Format(`20060102`), "Давно", "", "")
+	insertTask(t, database, now.Format(`20060102`), "Сегодня", "", "")
+	insertTask(t, database, now.AddDate(0, 0, 5).Format(`20060102`), "Через пять дней", "", "")
+	insertTask(t, database, now.AddDate(0, 0, 1).Format(`20060102`), "Завтра", "", "")
+
+	code, body := doRequest(t, srv, http.MethodGet, "/api/tasks/grouped", nil)
+	require.Equal(t, http.StatusOK, code)
+
+	var groups map[string][]map[string]string
+	require.NoError(t, json.Unmarshal(body, &groups))
+
+	titles := func(tasks []map[string]string) []string {
+		res := []string{}
+		for _, task := range tasks {
+			res = append(res, task["title"])
+		}
+		return res
+	}
+	assert.Equal(t, []string{"Давно", "Вчера"}, titles(groups["overdue"]))
+	assert.Equal(t, []string{"Сегодня"}, titles(groups["today"]))
+	assert.Equal(t, []string{"Завтра", "Через пять дней"}, titles(groups["upcoming"]))
+}
@@ -0,0 +1,87 @@
+package tests
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"go-task-manager-final_project/internal/api/handlers"
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer поднимает API поверх временной БД и возвращает тестовый сервер вместе с подключением к БД.
+// В отличие от интеграционных тестов, не требует запущенного веб‑сервера.
+func newTestServer(t *testing.T) (*httptest.Server, *sql.DB) {
+	t.Helper()
+
+	database, err := db.Init(filepath.Join(t.TempDir(), "scheduler.db"))
+	require.NoError(t, err)
+
+	router := chi.NewRouter()
+	handlers.Init(router, database)
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(func() {
+		srv.Close()
+		database.Close()
+	})
+	return srv, database
+}
+
+// doRequest выполняет запрос к тестовому серверу и возвращает код ответа и тело.
+// Если body не nil, оно кодируется в JSON.
+func doRequest(t *testing.T, srv *httptest.Server, method, path string, body any) (int, []byte) {
+	t.Helper()
+
+	resp, data := doRequestWithHeaders(t, srv, method, path, body, nil)
+	return resp.StatusCode, data
+}
+
+// doRequestWithHeaders выполняет запрос к тестовому серверу с дополнительными заголовками
+// и возвращает ответ (для проверки заголовков) и прочитанное тело.
+func doRequestWithHeaders(t *testing.T, srv *httptest.Server, method, path string, body any, headers map[string]string) (*http.Response, []byte) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, srv.URL+path, reader)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, data
+}
+
+// insertTask добавляет задачу напрямую в БД, минуя проверки API, и возвращает её ID.
+func insertTask(t *testing.T, database *sql.DB, date, title, comment, repeat string) string {
+	t.Helper()
+
+	res, err := database.Exec(`INSERT INTO scheduler (date, title, comment, repeat) VALUES (?, ?, ?, ?)`,
+		date, title, comment, repeat)
+	require.NoError(t, err)
+	id, err := res.LastInsertId()
+	require.NoError(t, err)
+	return strconv.FormatInt(id, 10)
+}
@@ -0,0 +1,121 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddTaskIdempotencyKey(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	task := map[string]any{"date": "today", "title": "Оплатить счёт"}
+
+	first, firstBody := doRequestWithHeaders(t, srv, http.MethodPost, "/api/task", task,
+		map[string]string{"Idempotency-Key": "key-1"})
+	require.Equal(t, http.StatusCreated, first.StatusCode)
+
+	second, secondBody := doRequestWithHeaders(t, srv, http.MethodPost, "/api/task", task,
+		map[string]string{"Idempotency-Key": "key-1"})
+	assert.Equal(t, http.StatusCreated, second.StatusCode)
+	assert.Equal(t, string(firstBody), string(secondBody))
+
+	var count int
+	require.NoError(t, database.QueryRow(`SELECT count(id) FROM scheduler`).Scan(&count))
+	assert.Equal(t, 1, count)
+
+	third, thirdBody := doRequestWithHeaders(t, srv, http.MethodPost, "/api/task", task,
+		map[string]string{"Idempotency-Key": "key-2"})
+	assert.Equal(t, http.StatusCreated, third.StatusCode)
+	assert.NotEqual(t, string(firstBody), string(thirdBody))
+
+	require.NoError(t, database.QueryRow(`SELECT count(id) FROM scheduler`).Scan(&count))
+	assert.Equal(t, 2, count)
+}
+
+func TestAddTaskIdempotencyKeyConcurrent(t *testing.T) {
+	_, database := newTestServer(t)
+
+	const workers = 8
+	ids := make([]int64, workers)
+	errs := make([]error, workers)
+
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			task := &db.Task{Date: "20300101", Title: "Оплатить счёт"}
+			var result db.IdempotentResult
+			result, errs[i] = db.AddTaskIdempotent(database, task, "key-concurrent", "hash", time.Hour,
+				func(created *db.Task) ([]byte, error) { return json.Marshal(created) })
+			ids[i] = result.TaskID
+		}()
+	}
+	wg.Wait()
+
+	for i := range workers {
+		require.NoError(t, errs[i])
+		assert.Equal(t, ids[0], ids[i])
+	}
+
+	var count int
+	require.NoError(t, database.QueryRow(`SELECT count(id) FROM scheduler`).Scan(&count))
+	assert.Equal(t, 1, count)
+}
+
+func TestAddTaskIdempotencyKeyDeletedTask(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	task := map[string]any{"date": "today", "title": "Оплатить счёт"}
+	headers := map[string]string{"Idempotency-Key": "key-deleted"}
+
+	first, body := doRequestWithHeaders(t, srv, http.MethodPost, "/api/task", task, headers)
+	require.Equal(t, http.StatusCreated, first.StatusCode)
+	var created struct {
+		ID int64 `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(body, &created))
+
+	code, _ := doRequest(t, srv, http.MethodDelete, "/api/task?id="+strconv.FormatInt(created.ID, 10), nil)
+	require.Equal(t, http.StatusOK, code)
+
+	// Повтор с тем же ключом не создаёт задачу заново, а сообщает, что она удалена
+	second, _ := doRequestWithHeaders(t, srv, http.MethodPost, "/api/task", task, headers)
+	assert.Equal(t, http.StatusGone, second.StatusCode)
+}
+
+func TestAddTaskIdempotencyKeyReplay(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	task := map[string]any{"date": "today", "title": "Оплатить счёт"}
+	headers := map[string]string{"Idempotency-Key": "key-replay"}
+
+	first, firstBody := doRequestWithHeaders(t, srv, http.MethodPost, "/api/task", task, headers)
+	require.Equal(t, http.StatusCreated, first.StatusCode)
+	var created struct {
+		ID int64 `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(firstBody, &created))
+	id := strconv.FormatInt(created.ID, 10)
+
+	// Задача изменена между повторами - повтор всё равно возвращает исходный ответ без изменений
+	code, body := doRequest(t, srv, http.MethodPatch, "/api/task?id="+id, map[string]any{"title": "Счёт оплачен"})
+	require.Equal(t, http.StatusOK, code, string(body))
+	second, secondBody := doRequestWithHeaders(t, srv, http.MethodPost, "/api/task", task, headers)
+	assert.Equal(t, http.StatusCreated, second.StatusCode)
+	assert.Equal(t, string(firstBody), string(secondBody))
+
+	// Тот же ключ с другим телом запроса отклоняется
+	other := map[string]any{"date": "today", "title": "Другая задача"}
+	third, _ := doRequestWithHeaders(t, srv, http.MethodPost, "/api/task", other, headers)
+	assert.Equal(t, http.StatusUnprocessableEntity, third.StatusCode)
+}
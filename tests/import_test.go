@@ -0,0 +1,115 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportKeepIDs(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	future := time.Now().AddDate(0, 0, 5).Format(`20060102`)
+	payload := []map[string]any{
+		{"id": "7", "date": future, "title": "Перенесённая задача", "repeat": "d 3"},
+		{"id": "42", "date": future, "title": "Ещё одна", "archived": true},
+	}
+
+	// Свежая БД: исходные ID сохраняются
+	code, body := doRequest(t, srv, http.MethodPost, "/api/import?keep_ids=true", payload)
+	require.Equal(t, http.StatusCreated, code, string(body))
+	var resp map[string][]int64
+	require.NoError(t, json.Unmarshal(body, &resp))
+	assert.Equal(t, []int64{7, 42}, resp["ids"])
+
+	task, err := db.GetTask(database, "42")
+	require.NoError(t, err)
+	assert.Equal(t, "Ещё одна", task.Title)
+	assert.True(t, task.Archived)
+
+	// Совпадение с существующим ID - 409 с номером ID, ни одна задача не добавляется
+	code, body = doRequest(t, srv, http.MethodPost, "/api/import?keep_ids=true", []map[string]any{
+		{"id": "8", "date": future, "title": "Новая"},
+		{"id": "7", "date": future, "title": "Дубликат"},
+	})
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Contains(t, string(body), "7")
+	var total int
+	require.NoError(t, database.QueryRow(`SELECT COUNT(*) FROM scheduler`).Scan(&total))
+	assert.Equal(t, 2, total)
+
+	// По умолчанию ID назначаются заново
+	code, body = doRequest(t, srv, http.MethodPost, "/api/import", payload)
+	require.Equal(t, http.StatusCreated, code, string(body))
+	require.NoError(t, json.Unmarshal(body, &resp))
+	assert.Equal(t, []int64{43, 44}, resp["ids"])
+
+	// Без ID сохранить их нельзя
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/import?keep_ids=true", []map[string]any{
+		{"date": future, "title": "Без ID"},
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+}
+
+func TestImportRoundTrip(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	// Прошедшая выполненная задача, прошедшая повторяющаяся и задача на субботу 27.01.2024
+	done := insertTask(t, database, "20240110", "Выполненная", "", "")
+	require.NoError(t, db.UpdateStatus(database, done, db.StatusDone))
+	insertTask(t, database, "20240115", "Повторяющаяся", "", "d 7")
+	insertTask(t, database, "20240127", "Суббота", "", "")
+
+	code, exported := doRequest(t, srv, http.MethodGet, "/api/export", nil)
+	require.Equal(t, http.StatusOK, code)
+
+	// Перенос в пустую БД с исходными ID не меняет ни даты, ни состояние задач
+	target, targetDB := newTestServer(t)
+	req, err := http.NewRequest(http.MethodPost, target.URL+"/api/import?keep_ids=true", bytes.NewReader(exported))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := target.Client().Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var source []db.Task
+	require.NoError(t, json.Unmarshal(exported, &source))
+	require.Len(t, source, 3)
+	for _, want := range source {
+		got, err := db.GetTask(targetDB, want.ID)
+		require.NoError(t, err)
+		assert.Equal(t, want.Date, got.Date, want.Title)
+		assert.Equal(t, want.Repeat, got.Repeat, want.Title)
+		assert.Equal(t, want.Status, got.Status, want.Title)
+	}
+}
+
+func TestImportInvalidUTF8(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	body := append([]byte(`[{"date":"20240126","title":"bad `), 0xff, 0xfe)
+	body = append(body, []byte(`"}]`)...)
+
+	// Некорректный UTF-8 отклоняется, а не заменяется на U+FFFD
+	for _, path := range []string{"/api/import", "/api/import/validate"} {
+		req, err := http.NewRequest(http.MethodPost, srv.URL+path, bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, path)
+	}
+
+	var count int
+	require.NoError(t, database.QueryRow(`SELECT count(id) FROM scheduler`).Scan(&count))
+	assert.Zero(t, count)
+}
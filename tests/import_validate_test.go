@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportValidate(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	rows := []map[string]any{
+		{"date": "20240126", "title": "Корректная задача"},
+		{"date": "20240126", "title": ""},
+		{"date": "20240192", "title": "Плохая дата"},
+		{"date": "today", "title": "Повтор", "repeat": "d 7"},
+		{"date": "20990101", "title": "Плохой повтор", "repeat": "ooops"},
+	}
+	code, body := doRequest(t, srv, http.MethodPost, "/api/import/validate", rows)
+	require.Equal(t, http.StatusOK, code)
+
+	var resp struct {
+		Results []struct {
+			Index int    `json:"index"`
+			Valid bool   `json:"valid"`
+			Error string `json:"error"`
+		} `json:"results"`
+	}
+	require.NoError(t, json.Unmarshal(body, &resp))
+	require.Len(t, resp.Results, len(rows))
+
+	for i, valid := range []bool{true, false, false, true, false} {
+		assert.Equal(t, i, resp.Results[i].Index)
+		assert.Equal(t, valid, resp.Results[i].Valid, rows[i])
+		assert.Equal(t, valid, resp.Results[i].Error == "", rows[i])
+	}
+
+	// Проверка не записывает ничего в БД
+	var count int
+	require.NoError(t, database.QueryRow(`SELECT count(id) FROM scheduler`).Scan(&count))
+	assert.Zero(t, count)
+}
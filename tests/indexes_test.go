@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// indexNames возвращает имена индексов из sqlite_master.
+func indexNames(t *testing.T, database *sql.DB) map[string]bool {
+	t.Helper()
+
+	rows, err := database.Query(`SELECT name FROM sqlite_master WHERE type = 'index'`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	names := map[string]bool{}
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		names[name] = true
+	}
+	require.NoError(t, rows.Err())
+	return names
+}
+
+func TestSupportingIndexes(t *testing.T) {
+	_, database := newTestServer(t)
+
+	names := indexNames(t, database)
+	assert.True(t, names["idx_scheduler_date"])
+	assert.True(t, names["idx_scheduler_repeat"])
+	assert.True(t, names["idx_completions_completed_at"])
+	assert.True(t, names["idx_scheduler_created_at"])
+	// Колонки priority в схеме нет - индекс не создаётся
+	assert.False(t, names["idx_scheduler_priority"])
+}
+
+func TestSupportingIndexesForExistingColumns(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "scheduler.db")
+
+	// БД, в которой уже есть колонка created_at
+	old, err := sql.Open("sqlite", dbFile)
+	require.NoError(t, err)
+	_, err = old.Exec(`CREATE TABLE scheduler (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		date CHAR(8) NOT NULL DEFAULT '',
+		title VARCHAR(255) NOT NULL,
+		comment TEXT,
+		repeat VARCHAR(128),
+		created_at INTEGER
+	)`)
+	require.NoError(t, err)
+	require.NoError(t, old.Close())
+
+	database, err := db.Init(dbFile)
+	require.NoError(t, err)
+	defer database.Close()
+
+	assert.True(t, indexNames(t, database)["idx_scheduler_created_at"])
+}
@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWTTTL(t *testing.T) {
+	prevPassword, prevSecret, prevTTL := config.Password, config.JWTSecret, config.JWTTTL
+	t.Cleanup(func() { config.Password, config.JWTSecret, config.JWTTTL = prevPassword, prevSecret, prevTTL })
+	config.Password, config.JWTSecret = "12345678", "test-secret"
+
+	srv, _ := newTestServer(t)
+
+	// expiresAt выполняет вход и возвращает время истечения выданного токена
+	expiresAt := func() int64 {
+		code, body := doRequest(t, srv, http.MethodPost, "/api/signin", map[string]any{"password": "12345678"})
+		require.Equal(t, http.StatusOK, code, string(body))
+		var signin map[string]string
+		require.NoError(t, json.Unmarshal(body, &signin))
+
+		resp, body := doRequestWithHeaders(t, srv, http.MethodGet, "/api/session", nil,
+			map[string]string{"Authorization": "Bearer " + signin["token"]})
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+		var session struct {
+			ExpiresAt int64 `json:"expires_at"`
+		}
+		require.NoError(t, json.Unmarshal(body, &session))
+		return session.ExpiresAt
+	}
+
+	// Время жизни из TODO_JWT_TTL
+	config.JWTTTL = "30m"
+	assert.InDelta(t, time.Now().Add(30*time.Minute).Unix(), expiresAt(), 60)
+
+	// Незаданное, некорректное и неположительное значение - 8 часов по умолчанию
+	for _, ttl := range []string{"", "abc", "0s", "-1h"} {
+		config.JWTTTL = ttl
+		assert.InDelta(t, time.Now().Add(8*time.Hour).Unix(), expiresAt(), 60, "TODO_JWT_TTL=%q", ttl)
+	}
+}
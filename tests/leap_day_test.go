@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/config"
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+	"go-task-manager-final_project/internal/server"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeapDayYearlyRepeat(t *testing.T) {
+	prev := config.LeapDayPolicy
+	t.Cleanup(func() {
+		config.LeapDayPolicy = prev
+		scheduler.SetLeapDayPolicy(scheduler.LeapDayFeb28)
+	})
+
+	// walk возвращает повторения задачи от 29.02.2024, вычисляя каждое следующее от предыдущего
+	// (предыдущее повторение - и стартовая дата, и текущий момент, как при отметке выполнения)
+	walk := func(steps int) []string {
+		dates := []string{}
+		date := "20240229"
+		for i := 0; i < steps; i++ {
+			now, err := time.Parse(scheduler.DateFormat, date)
+			require.NoError(t, err)
+			date, err = scheduler.NextDate(now, date, "y")
+			require.NoError(t, err)
+			dates = append(dates, date)
+		}
+		return dates
+	}
+
+	// По умолчанию в невисокосный год задача переносится на 28 февраля и остаётся на этой дате
+	config.LeapDayPolicy = ""
+	require.NoError(t, server.ConfigureLeapDayPolicy())
+	assert.Equal(t, []string{"20250228", "20260228", "20270228", "20280228", "20290228"}, walk(5))
+
+	// Через 2100 год (невисокосный, хотя делится на 4)
+	next, err := scheduler.NextDate(time.Date(2099, time.March, 1, 0, 0, 0, 0, time.UTC), "20240229", "y")
+	require.NoError(t, err)
+	assert.Equal(t, "21000228", next)
+
+	// Политика "leap" пропускает невисокосные годы
+	config.LeapDayPolicy = "leap"
+	require.NoError(t, server.ConfigureLeapDayPolicy())
+	assert.Equal(t, []string{"20280229", "20320229", "20360229"}, walk(3))
+
+	next, err = scheduler.NextDate(time.Date(2096, time.March, 1, 0, 0, 0, 0, time.UTC), "20240229", "y")
+	require.NoError(t, err)
+	assert.Equal(t, "21040229", next)
+
+	// Остальные даты правило не затрагивает
+	next, err = scheduler.NextDate(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), "20240301", "y")
+	require.NoError(t, err)
+	assert.Equal(t, "20250301", next)
+
+	// Проверка даты по правилу учитывает ту же политику
+	matches, _, err := scheduler.CheckRepeat("20250228", "20240229", "y")
+	require.NoError(t, err)
+	assert.False(t, matches)
+	matches, _, err = scheduler.CheckRepeat("20280229", "20240229", "y")
+	require.NoError(t, err)
+	assert.True(t, matches)
+
+	scheduler.SetLeapDayPolicy(scheduler.LeapDayFeb28)
+	matches, _, err = scheduler.CheckRepeat("20250228", "20240229", "y")
+	require.NoError(t, err)
+	assert.True(t, matches)
+	matches, _, err = scheduler.CheckRepeat("20250301", "20240229", "y")
+	require.NoError(t, err)
+	assert.False(t, matches)
+	// 28 февраля сохраняется и в следующий високосный год
+	matches, _, err = scheduler.CheckRepeat("20280228", "20240229", "y")
+	require.NoError(t, err)
+	assert.True(t, matches)
+	matches, _, err = scheduler.CheckRepeat("20280229", "20240229", "y")
+	require.NoError(t, err)
+	assert.False(t, matches)
+
+	// Неизвестное значение отклоняется
+	config.LeapDayPolicy = "mar1"
+	assert.Error(t, server.ConfigureLeapDayPolicy())
+}
+
+func TestLeapDayDoneChain(t *testing.T) {
+	t.Cleanup(func() { scheduler.SetLeapDayPolicy(scheduler.LeapDayFeb28) })
+
+	// done возвращает даты задачи от 29.02.2024 после каждой из steps отметок выполнения
+	done := func(steps int) []string {
+		srv, database := newTestServer(t)
+		id := insertTask(t, database, "20240229", "Годовщина", "", "y")
+		dates := []string{}
+		for i := 0; i < steps; i++ {
+			code, body := doRequest(t, srv, http.MethodPost, "/api/task/done?id="+id, nil)
+			require.Equal(t, http.StatusOK, code, string(body))
+			task, err := db.GetTask(database, id)
+			require.NoError(t, err)
+			dates = append(dates, task.Date)
+		}
+		return dates
+	}
+
+	scheduler.SetLeapDayPolicy(scheduler.LeapDayFeb28)
+	assert.Equal(t, []string{"20250228", "20260228", "20270228", "20280228", "20290228"}, done(5))
+
+	scheduler.SetLeapDayPolicy(scheduler.LeapDayLeapYears)
+	assert.Equal(t, []string{"20280229", "20320229", "20360229"}, done(3))
+}
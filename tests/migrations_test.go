@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appliedMigrations возвращает версии из таблицы schema_migrations по возрастанию.
+func appliedMigrations(t *testing.T, database *sql.DB) []int {
+	t.Helper()
+
+	rows, err := database.Query(`SELECT version FROM schema_migrations ORDER BY version`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	versions := []int{}
+	for rows.Next() {
+		var version int
+		require.NoError(t, rows.Scan(&version))
+		versions = append(versions, version)
+	}
+	require.NoError(t, rows.Err())
+	return versions
+}
+
+func TestMigrationsUpgradeOldSchema(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "old.db")
+
+	// БД первой версии: без новых колонок, служебных таблиц и schema_migrations
+	old, err := sql.Open("sqlite", dbFile)
+	require.NoError(t, err)
+	_, err = old.Exec(`CREATE TABLE scheduler (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		date CHAR(8) NOT NULL DEFAULT '',
+		title VARCHAR(255) NOT NULL,
+		comment TEXT,
+		repeat VARCHAR(128),
+		color VARCHAR(16) NOT NULL DEFAULT ''
+	)`)
+	require.NoError(t, err)
+	_, err = old.Exec(`INSERT INTO scheduler (date, title, comment, repeat, color) VALUES ('20240126', 'Старая задача', 'Комментарий', 'd 7', 'red')`)
+	require.NoError(t, err)
+	require.NoError(t, old.Close())
+
+	database, err := db.Init(dbFile)
+	require.NoError(t, err)
+
+	// Применены все миграции, данные сохранены, уже существовавшая колонка не мешает обновлению
+	versions := appliedMigrations(t, database)
+	require.NotEmpty(t, versions)
+	tasks, err := db.GetTasks(database, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Старая задача", tasks[0].Title)
+	assert.Equal(t, "red", tasks[0].Color)
+	assert.False(t, tasks[0].Archived)
+
+	// Новая схема работает: задачи создаются, служебные таблицы есть
+	_, err = db.AddTask(database, &db.Task{Date: "20240127", Title: "Новая задача", DurationMinutes: 30})
+	require.NoError(t, err)
+	_, err = database.Exec(`SELECT COUNT(*) FROM idempotency_keys`)
+	require.NoError(t, err)
+	_, err = database.Exec(`SELECT COUNT(*) FROM completions`)
+	require.NoError(t, err)
+	require.NoError(t, database.Close())
+
+	// Повторный запуск не применяет миграции заново
+	database, err = db.Init(dbFile)
+	require.NoError(t, err)
+	assert.Equal(t, versions, appliedMigrations(t, database))
+	tasks, err = db.GetTasks(database, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 2)
+	require.NoError(t, database.Close())
+}
+
+func TestMigrationsNewDatabase(t *testing.T) {
+	database, err := db.Init(filepath.Join(t.TempDir(), "new.db"))
+	require.NoError(t, err)
+	defer database.Close()
+
+	// В новой БД версии применяются по порядку, начиная с первой
+	versions := appliedMigrations(t, database)
+	require.NotEmpty(t, versions)
+	for i, version := range versions {
+		assert.Equal(t, i+1, version)
+	}
+}
+
+func TestMigrationsRejectNewerSchema(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "new.db")
+
+	database, err := db.Init(dbFile)
+	require.NoError(t, err)
+	_, err = database.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (1000, 0)`)
+	require.NoError(t, err)
+	require.NoError(t, database.Close())
+
+	// Схема из более новой версии приложения не открывается
+	_, err = db.Init(dbFile)
+	assert.ErrorContains(t, err, "newer than supported")
+}
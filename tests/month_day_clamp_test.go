@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/scheduler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthDayClamp(t *testing.T) {
+	t.Cleanup(func() { scheduler.SetMonthDayClamp(false) })
+
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("strict", func(t *testing.T) {
+		scheduler.SetMonthDayClamp(false)
+
+		// 31 февраля не существует - правило невыполнимо
+		_, err := scheduler.NextDate(now, "20240101", "m 31 2")
+		assert.Error(t, err)
+
+		// Февраль пропускается, ближайшее 31 число - в марте
+		next, err := scheduler.NextDate(now, "20240201", "m 31")
+		require.NoError(t, err)
+		assert.Equal(t, "20240331", next)
+	})
+
+	t.Run("clamp", func(t *testing.T) {
+		scheduler.SetMonthDayClamp(true)
+
+		// 31 февраля превращается в последний день февраля (високосный год)
+		next, err := scheduler.NextDate(now, "20240101", "m 31 2")
+		require.NoError(t, err)
+		assert.Equal(t, "20240229", next)
+
+		next, err = scheduler.NextDate(now, "20240201", "m 30")
+		require.NoError(t, err)
+		assert.Equal(t, "20240229", next)
+
+		// Невисокосный год
+		next, err = scheduler.NextDate(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), "20250101", "m 31 2")
+		require.NoError(t, err)
+		assert.Equal(t, "20250228", next)
+	})
+}
+
+func TestMonthDayShortMonths(t *testing.T) {
+	t.Cleanup(func() { scheduler.SetMonthDayClamp(false) })
+
+	tbl := []struct {
+		clamp  bool
+		now    string
+		repeat string
+		want   string
+	}{
+		// Без клампинга короткие месяцы пропускаются
+		{false, "20240401", "m 31", "20240531"},
+		{false, "20240201", "m 30", "20240330"},
+		{false, "20240201", "m 29", "20240229"},
+		{false, "20250201", "m 29", "20250329"},
+		{false, "20230101", "m 29 2", "20240229"},
+		// С клампингом день превращается в последний день месяца
+		{true, "20240401", "m 31", "20240430"},
+		{true, "20240401", "m 31 4", "20240430"},
+		{true, "20240201", "m 31", "20240229"},
+		{true, "20250201", "m 31", "20250228"},
+		{true, "20250201", "m 29 2", "20250228"},
+		// Оба дня после клампинга приходятся на 30 апреля
+		{true, "20240401", "m 30,31", "20240430"},
+	}
+	for _, v := range tbl {
+		scheduler.SetMonthDayClamp(v.clamp)
+		now, err := time.Parse(scheduler.DateFormat, v.now)
+		require.NoError(t, err)
+		next, err := scheduler.NextDate(now, v.now, v.repeat)
+		require.NoError(t, err, "%s %s", v.now, v.repeat)
+		assert.Equal(t, v.want, next, "clamp=%t %s %s", v.clamp, v.now, v.repeat)
+	}
+
+	// Без клампинга правило, которое не выполняется ни в одном месяце, возвращает ошибку, а не зацикливается
+	scheduler.SetMonthDayClamp(false)
+	for _, repeat := range []string{"m 31 4,6,9,11", "m 30,31 2"} {
+		_, err := scheduler.NextDate(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), "20240101", repeat)
+		assert.Error(t, err, repeat)
+	}
+}
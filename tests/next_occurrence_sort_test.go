@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortByNextOccurrence(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	now := time.Now()
+	day := func(offset int) string { return now.AddDate(0, 0, offset).Format(`20060102`) }
+
+	// Сохранённые даты повторяющихся задач устарели: ближайшие повторения отличаются от них
+	weekly := insertTask(t, database, day(-10), "Раз в неделю", "", "d 7") // ближайшее - через 4 дня
+	daily := insertTask(t, database, day(-3), "Каждый день", "", "d 1")    // ближайшее - сегодня
+	soon := insertTask(t, database, day(2), "Через два дня", "", "")
+	later := insertTask(t, database, day(5), "Через пять дней", "", "")
+
+	list := func(query string) []string {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/tasks"+query, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp struct {
+			Tasks []struct {
+				ID string `json:"id"`
+			} `json:"tasks"`
+		}
+		require.NoError(t, json.Unmarshal(body, &resp))
+		ids := make([]string, 0, len(resp.Tasks))
+		for _, task := range resp.Tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	// По умолчанию - по сохранённой дате
+	assert.Equal(t, []string{weekly, daily, soon, later}, list(""))
+	// По ближайшему повторению от сегодняшнего дня
+	assert.Equal(t, []string{daily, soon, weekly, later}, list("?sort=next_occurrence"))
+
+	code, _ := doRequest(t, srv, http.MethodGet, "/api/tasks?sort=title", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,29 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextDateDefaultNow(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	// Без now расчёт ведётся от сегодняшней даты
+	now := time.Now()
+	start := now.AddDate(0, 0, -3).Format(`20060102`)
+	code, body := doRequest(t, srv, http.MethodGet, "/api/nextdate?date="+start+"&repeat=d+1", nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, now.AddDate(0, 0, 1).Format(`20060102`), string(body))
+
+	// Переданный now используется как есть
+	code, body = doRequest(t, srv, http.MethodGet, "/api/nextdate?now=20240126&date=20240113&repeat=d+7", nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "20240127", string(body))
+
+	// Некорректный now - ошибка
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/nextdate?now=ooops&date=20240113&repeat=d+7", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
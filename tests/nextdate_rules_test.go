@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextDateWeekdayDayAfterStart(t *testing.T) {
+	// 26.01.2024 - пятница
+	now := time.Date(2024, time.January, 26, 0, 0, 0, 0, time.UTC)
+
+	tbl := []struct {
+		dstart string
+		repeat string
+		want   string
+	}{
+		// Подходящий день - сразу после стартовой даты (суббота)
+		{"20240126", "w 6", "20240127"},
+		{"20240126", "w 6,7", "20240127"},
+		// Стартовая дата в прошлом, подходящий день - сразу после now
+		{"20240110", "w 6", "20240127"},
+		// Стартовая дата в будущем, подходящий день - следующий за ней (вторник)
+		{"20240205", "w 2", "20240206"},
+	}
+	for _, v := range tbl {
+		next, err := scheduler.NextDate(now, v.dstart, v.repeat)
+		require.NoError(t, err, "%s %s", v.dstart, v.repeat)
+		assert.Equal(t, v.want, next, "%s %s", v.dstart, v.repeat)
+	}
+}
+
+func TestNextDateMonthDayDayAfterStart(t *testing.T) {
+	// 14.01.2024
+	now := time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC)
+
+	tbl := []struct {
+		dstart string
+		repeat string
+		want   string
+	}{
+		// Подходящий день месяца - сразу после стартовой даты
+		{"20240114", "m 15", "20240115"},
+		{"20240114", "m 15 1", "20240115"},
+		{"20240130", "m -1", "20240131"},
+		// Стартовая дата в прошлом, подходящий день - сразу после now
+		{"20231201", "m 15", "20240115"},
+		// Стартовая дата в будущем
+		{"20240314", "m 15,20", "20240315"},
+	}
+	for _, v := range tbl {
+		next, err := scheduler.NextDate(now, v.dstart, v.repeat)
+		require.NoError(t, err, "%s %s", v.dstart, v.repeat)
+		assert.Equal(t, v.want, next, "%s %s", v.dstart, v.repeat)
+	}
+}
+
+func TestNextDateHours(t *testing.T) {
+	// 30.01.2024
+	now := time.Date(2024, time.January, 30, 0, 0, 0, 0, time.UTC)
+
+	tbl := []struct {
+		dstart string
+		repeat string
+		want   string
+	}{
+		// Несколько повторений в сутки - следующая дата наступает на следующий день после now
+		{"20240130", "h 6", "20240131"},
+		{"20240130", "h 1", "20240131"},
+		{"20240130", "h 24", "20240131"},
+		// 36 часов от 30.01 - 31.01 12:00: дата уже после now
+		{"20240130", "h 36", "20240131"},
+		// Переход через границу месяца: 29.01 -> 30.01 12:00 (не позже now) -> 01.02 00:00
+		{"20240129", "h 36", "20240201"},
+		{"20240128", "h 72", "20240131"},
+		// Переход через границу года: повторения по чётным дням января, 30.01 не позже now -> 01.02
+		{"20231231", "h 48", "20240201"},
+		// Стартовая дата в будущем - сама является первым повторением
+		{"20240228", "h 30", "20240228"},
+		// Максимальный интервал (400 дней, с учётом 29.02.2024)
+		{"20240130", "h 9600", "20250305"},
+	}
+	for _, v := range tbl {
+		next, err := scheduler.NextDate(now, v.dstart, v.repeat)
+		require.NoError(t, err, "%s %s", v.dstart, v.repeat)
+		assert.Equal(t, v.want, next, "%s %s", v.dstart, v.repeat)
+	}
+
+	// Интервалы короче суток в последний день месяца и года: повторения переходят на следующий месяц
+	wrap := []struct {
+		now    time.Time
+		dstart string
+		repeat string
+		want   string
+	}{
+		// 31.01 18:00, повторения 00:00, 07:00, 14:00, 21:00 -> 01.02 04:00
+		{time.Date(2024, time.January, 31, 18, 0, 0, 0, time.UTC), "20240131", "h 7", "20240201"},
+		// 29.02 високосного года - следующее повторение 01.03
+		{time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC), "20240227", "h 5", "20240301"},
+		// 31.12 - повторения 30.12 00:00, 30.12 23:00, 31.12 22:00 -> 01.01 21:00
+		{time.Date(2023, time.December, 31, 8, 0, 0, 0, time.UTC), "20231230", "h 23", "20240101"},
+	}
+	for _, v := range wrap {
+		next, err := scheduler.NextDate(v.now, v.dstart, v.repeat)
+		require.NoError(t, err, "%s %s", v.dstart, v.repeat)
+		assert.Equal(t, v.want, next, "%s %s", v.dstart, v.repeat)
+	}
+
+	// Некорректные правила
+	for _, repeat := range []string{"h", "h x", "h 0", "h -6", "h 9601", "h 6 12"} {
+		_, err := scheduler.NextDate(now, "20240130", repeat)
+		assert.Error(t, err, repeat)
+	}
+}
+
+func TestHoursRuleDone(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	// Отметка выполнения отсчитывает повторения от полуночи даты задачи:
+	// 01.02 00:00 + 36 часов = 02.02 12:00, время суток не сохраняется, поэтому следующий шаг - от 02.02 00:00
+	id := insertTask(t, database, "20240201", "Полив", "", "h 36")
+	dates := []string{}
+	for range 3 {
+		code, body := doRequest(t, srv, http.MethodPost, "/api/task/done?id="+id, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		task, err := db.GetTask(database, id)
+		require.NoError(t, err)
+		dates = append(dates, task.Date)
+	}
+	assert.Equal(t, []string{"20240202", "20240203", "20240204"}, dates)
+
+	// Интервал короче суток - задача переносится на следующий день
+	id = insertTask(t, database, "20240131", "Проветрить", "", "h 6")
+	code, body := doRequest(t, srv, http.MethodPost, "/api/task/done?id="+id, nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+	task, err := db.GetTask(database, id)
+	require.NoError(t, err)
+	assert.Equal(t, "20240201", task.Date)
+}
+
+func TestNextDateFutureStart(t *testing.T) {
+	// 26.01.2024 - пятница
+	now := time.Date(2024, time.January, 26, 0, 0, 0, 0, time.UTC)
+
+	tbl := []struct {
+		dstart string
+		repeat string
+		want   string
+	}{
+		// Стартовая дата позже now возвращается без изменений
+		{"20240127", "d 1", "20240127"},
+		{"20250101", "d 7", "20250101"},
+		{"20300615", "y", "20300615"},
+		// Для "w" и "m" стартовая дата - первый кандидат, если подходит под правило
+		{"20240203", "w 6", "20240203"},
+		{"20240203", "w 1", "20240205"},
+		{"20240215", "m 15", "20240215"},
+		{"20240216", "m 15", "20240315"},
+		{"20240229", "m -1", "20240229"},
+		// Стартовая дата, равная now, по-прежнему сдвигается
+		{"20240126", "d 1", "20240127"},
+		{"20240126", "y", "20250126"},
+		{"20240126", "w 5", "20240202"},
+		{"20240126", "m 26", "20240226"},
+	}
+	for _, v := range tbl {
+		next, err := scheduler.NextDate(now, v.dstart, v.repeat)
+		require.NoError(t, err, "%s %s", v.dstart, v.repeat)
+		assert.Equal(t, v.want, next, "%s %s", v.dstart, v.repeat)
+	}
+
+	// Правило проверяется, даже если стартовая дата уже позже now
+	_, err := scheduler.NextDate(now, "20250101", "d 401")
+	assert.Error(t, err)
+}
@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeDate(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	normalize := func(value string) (int, map[string]string) {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/date/normalize?value="+url.QueryEscape(value), nil)
+		var m map[string]string
+		require.NoError(t, json.Unmarshal(body, &m))
+		return code, m
+	}
+
+	// Все поддерживаемые форматы приводятся к формату хранения
+	for _, value := range []string{"20240115", "15.01.2024", "2024-01-15"} {
+		code, m := normalize(value)
+		require.Equal(t, http.StatusOK, code, value)
+		assert.Equal(t, "20240115", m["date"], value)
+	}
+
+	// Нераспознанная и отсутствующая дата - 400 с описанием ошибки
+	for _, value := range []string{"15/01/2024", "20240230", ""} {
+		code, m := normalize(value)
+		assert.Equal(t, http.StatusBadRequest, code, value)
+		assert.NotEmpty(t, m["error"], value)
+	}
+}
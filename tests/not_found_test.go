@@ -0,0 +1,31 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskNotFound(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	cases := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/api/task?id=999999"},
+		{http.MethodPost, "/api/task/done?id=999999"},
+		{http.MethodDelete, "/api/task?id=999999"},
+	}
+	for _, c := range cases {
+		code, body := doRequest(t, srv, c.method, c.path, nil)
+		require.Equal(t, http.StatusNotFound, code, "%s %s: %s", c.method, c.path, body)
+
+		var m map[string]string
+		require.NoError(t, json.Unmarshal(body, &m))
+		assert.NotEmpty(t, m["error"])
+	}
+}
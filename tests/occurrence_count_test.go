@@ -0,0 +1,40 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOccurrenceCount(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	occurrenceCount := func(id, from, to string) map[string]any {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/task/occurrence-count?id="+id+"&from="+from+"&to="+to, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var m map[string]any
+		require.NoError(t, json.Unmarshal(body, &m))
+		return m
+	}
+
+	// Ежедневная задача за 30 дней
+	daily := insertTask(t, database, "20240101", "Зарядка", "", "d 1")
+	assert.EqualValues(t, 30, occurrenceCount(daily, "20240101", "20240130")["count"])
+	// Диапазон, начинающийся позже даты задачи
+	assert.EqualValues(t, 10, occurrenceCount(daily, "20240301", "20240310")["count"])
+
+	// Еженедельная задача (по понедельникам) за первый квартал 2024 года
+	weekly := insertTask(t, database, "20240101", "Планёрка", "", "w 1")
+	assert.EqualValues(t, 13, occurrenceCount(weekly, "20240101", "20240331")["count"])
+
+	// Обход ограничен
+	resp := occurrenceCount(daily, "20240101", "20991231")
+	assert.EqualValues(t, 10000, resp["count"])
+	assert.Equal(t, true, resp["truncated"])
+
+	code, _ := doRequest(t, srv, http.MethodGet, "/api/task/occurrence-count?id="+daily+"&from=20240201&to=20240101", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
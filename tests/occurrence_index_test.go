@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOccurrenceIndex(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	// Еженедельная задача по понедельникам, начатая 01.01.2024 и выполненная четыре раза
+	weekly := insertTask(t, database, "20240129", "Планёрка", "", "w 1")
+	for _, date := range []string{"20240101", "20240108", "20240115", "20240122"} {
+		require.NoError(t, db.AddCompletion(database, weekly, date, time.Now()))
+	}
+	// Задача без отметок о выполнении - первое повторение
+	fresh := insertTask(t, database, "20240130", "Новая", "", "d 3")
+	once := insertTask(t, database, "20240131", "Разовая", "", "")
+
+	code, body := doRequest(t, srv, http.MethodGet, "/api/tasks?include=occurrence_index", nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+	var resp struct {
+		Tasks []struct {
+			ID              string `json:"id"`
+			Title           string `json:"title"`
+			OccurrenceIndex int    `json:"occurrence_index"`
+		} `json:"tasks"`
+	}
+	require.NoError(t, json.Unmarshal(body, &resp))
+
+	indexes := map[string]int{}
+	for _, task := range resp.Tasks {
+		assert.NotEmpty(t, task.Title)
+		indexes[task.ID] = task.OccurrenceIndex
+	}
+	assert.Equal(t, map[string]int{weekly: 5, fresh: 1, once: 1}, indexes)
+
+	// Без include номер повторения не выводится
+	code, body = doRequest(t, srv, http.MethodGet, "/api/tasks", nil)
+	require.Equal(t, http.StatusOK, code)
+	assert.NotContains(t, string(body), "occurrence_index")
+
+	// Значения include комбинируются
+	require.NoError(t, db.SetArchived(database, once, true))
+	code, body = doRequest(t, srv, http.MethodGet, "/api/tasks?include=archived,occurrence_index", nil)
+	require.Equal(t, http.StatusOK, code)
+	require.NoError(t, json.Unmarshal(body, &resp))
+	assert.Len(t, resp.Tasks, 3)
+
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/tasks?include=occurrence_index&view=summary", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTasksPagination(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	for i := 1; i <= 60; i++ {
+		insertTask(t, database, fmt.Sprintf("202401%02d", i%28+1), fmt.Sprintf("Задача %d", i), "", "")
+	}
+
+	list := func(query string) []string {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/tasks"+query, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp struct {
+			Tasks []struct {
+				ID string `json:"id"`
+			} `json:"tasks"`
+		}
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.NotNil(t, resp.Tasks)
+		ids := make([]string, 0, len(resp.Tasks))
+		for _, task := range resp.Tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	// По умолчанию - первые 50 задач
+	all := list("")
+	assert.Len(t, all, 50)
+
+	// Страницы не пересекаются и вместе дают весь список
+	first := list("?limit=25")
+	second := list("?limit=25&offset=25")
+	third := list("?limit=25&offset=50")
+	assert.Len(t, first, 25)
+	assert.Len(t, second, 25)
+	assert.Len(t, third, 10)
+	assert.Equal(t, all, append(first, second...))
+	assert.Len(t, list("?limit=100"), 60)
+
+	// Offset за концом списка - пустой массив, а не ошибка
+	assert.Empty(t, list("?offset=100"))
+
+	// Постраничный вывод работает и для поиска
+	assert.Len(t, list("?search=Задача&limit=10&offset=55"), 5)
+
+	// Некорректные значения - 400
+	for _, query := range []string{"?limit=-1", "?limit=0", "?limit=abc", "?limit=1000000", "?offset=-1", "?offset=x"} {
+		code, _ := doRequest(t, srv, http.MethodGet, "/api/tasks"+query, nil)
+		assert.Equal(t, http.StatusBadRequest, code, query)
+	}
+}
@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-task-manager-final_project/config"
+	"go-task-manager-final_project/internal/server"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// setPassword задаёт пароль, его хэш и секрет JWT на время теста.
+func setPassword(t *testing.T, password, hash string) {
+	prevPassword, prevHash, prevSecret := config.Password, config.PasswordHash, config.JWTSecret
+	t.Cleanup(func() {
+		config.Password, config.PasswordHash, config.JWTSecret = prevPassword, prevHash, prevSecret
+	})
+	config.Password, config.PasswordHash, config.JWTSecret = password, hash, "test-secret"
+}
+
+// bcryptHash возвращает bcrypt-хэш пароля с минимальной стоимостью (для скорости тестов).
+func bcryptHash(t *testing.T, password string) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return string(hash)
+}
+
+// signIn выполняет вход и возвращает код ответа и токен.
+func signIn(t *testing.T, srv *httptest.Server, password string) (int, string) {
+	code, body := doRequest(t, srv, http.MethodPost, "/api/signin", map[string]any{"password": password})
+	var resp map[string]string
+	require.NoError(t, json.Unmarshal(body, &resp))
+	return code, resp["token"]
+}
+
+func TestPasswordMode(t *testing.T) {
+	setPassword(t, "", "")
+	assert.Equal(t, config.PasswordModeNone, config.PasswordMode())
+	assert.False(t, config.AuthEnabled())
+
+	config.Password = "12345678"
+	assert.Equal(t, config.PasswordModePlain, config.PasswordMode())
+
+	// Хэш имеет приоритет над паролем в открытом виде
+	config.PasswordHash = bcryptHash(t, "secret-password")
+	assert.Equal(t, config.PasswordModeBcrypt, config.PasswordMode())
+	assert.True(t, config.AuthEnabled())
+}
+
+func TestSignInBcrypt(t *testing.T) {
+	setPassword(t, "", bcryptHash(t, "secret-password"))
+	srv, _ := newTestServer(t)
+
+	code, _ := signIn(t, srv, "wrong-password")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, token := signIn(t, srv, "secret-password")
+	require.Equal(t, http.StatusOK, code)
+	require.NotEmpty(t, token)
+	cookie := map[string]string{"Cookie": "token=" + token}
+	resp, _ := doRequestWithHeaders(t, srv, http.MethodGet, "/api/session", nil, cookie)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Смена хэша (даже для того же пароля) делает выданные токены недействительными
+	config.PasswordHash = bcryptHash(t, "secret-password")
+	resp, _ = doRequestWithHeaders(t, srv, http.MethodGet, "/api/session", nil, cookie)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestSignInPlaintext(t *testing.T) {
+	setPassword(t, "12345678", "")
+	srv, _ := newTestServer(t)
+
+	code, _ := signIn(t, srv, "87654321")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, token := signIn(t, srv, "12345678")
+	require.Equal(t, http.StatusOK, code)
+	resp, _ := doRequestWithHeaders(t, srv, http.MethodGet, "/api/session", nil,
+		map[string]string{"Cookie": "token=" + token})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestValidatePasswordHash(t *testing.T) {
+	setPassword(t, "", bcryptHash(t, "secret-password"))
+	assert.NoError(t, server.ValidatePassword())
+
+	config.PasswordHash = "not-a-bcrypt-hash"
+	err := server.ValidatePassword()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TODO_PASSWORD_HASH")
+}
@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"testing"
+
+	"go-task-manager-final_project/config"
+	"go-task-manager-final_project/internal/server"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePasswordLength(t *testing.T) {
+	prevPassword, prevLength := config.Password, config.MinPasswordLength
+	t.Cleanup(func() {
+		config.Password, config.MinPasswordLength = prevPassword, prevLength
+	})
+
+	// Короткий пароль при длине по умолчанию (8) - ошибка запуска
+	config.Password, config.MinPasswordLength = "12345", ""
+	err := server.ValidatePassword()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "too short")
+
+	// Пароль достаточной длины (длина в символах, а не в байтах)
+	config.Password = "пароль12"
+	assert.NoError(t, server.ValidatePassword())
+
+	// Минимальная длина настраивается
+	config.Password, config.MinPasswordLength = "12345", "4"
+	assert.NoError(t, server.ValidatePassword())
+	config.MinPasswordLength = "abc"
+	assert.Error(t, server.ValidatePassword())
+
+	// Без пароля аутентификация отключена - проверка не выполняется
+	config.Password, config.MinPasswordLength = "", ""
+	assert.NoError(t, server.ValidatePassword())
+}
@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatchTaskRepeat(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	future := time.Now().AddDate(0, 0, 3).Format(`20060102`)
+	id := insertTask(t, database, future, "Полив", "на балконе", "d 7")
+
+	// Отсутствующее поле repeat не меняет правило повторения
+	code, body := doRequest(t, srv, http.MethodPatch, "/api/task?id="+id, map[string]any{"title": "Полив цветов"})
+	require.Equal(t, http.StatusOK, code, string(body))
+	task, err := db.GetTask(database, id)
+	require.NoError(t, err)
+	assert.Equal(t, "Полив цветов", task.Title)
+	assert.Equal(t, "d 7", task.Repeat)
+	assert.Equal(t, "на балконе", task.Comment)
+	assert.Equal(t, future, task.Date)
+
+	// Явная пустая строка делает задачу разовой
+	code, body = doRequest(t, srv, http.MethodPatch, "/api/task?id="+id, map[string]any{"repeat": ""})
+	require.Equal(t, http.StatusOK, code, string(body))
+	task, err = db.GetTask(database, id)
+	require.NoError(t, err)
+	assert.Empty(t, task.Repeat)
+	assert.Equal(t, "Полив цветов", task.Title)
+	assert.Equal(t, future, task.Date)
+
+	// Заголовок нельзя очистить
+	code, _ = doRequest(t, srv, http.MethodPatch, "/api/task?id="+id, map[string]any{"title": ""})
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+
+	code, _ = doRequest(t, srv, http.MethodPatch, "/api/task", map[string]any{"repeat": ""})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// Некорректное правило повторения отклоняется и для задачи с датой в будущем
+	for _, repeat := range []string{"zzz 9", "d 0", "w 8"} {
+		code, body = doRequest(t, srv, http.MethodPatch, "/api/task?id="+id, map[string]any{"repeat": repeat})
+		assert.Equal(t, http.StatusUnprocessableEntity, code, repeat)
+	}
+	code, body = doRequest(t, srv, http.MethodPatch, "/api/task?id="+id, map[string]any{"repeat": "w 1,3"})
+	require.Equal(t, http.StatusOK, code, string(body))
+	task, err = db.GetTask(database, id)
+	require.NoError(t, err)
+	assert.Equal(t, "w 1,3", task.Repeat)
+}
@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go-task-manager-final_project/internal/api/handlers"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPingWithoutDB(t *testing.T) {
+	// Сервер без подключения к БД: ping всё равно должен отвечать
+	router := chi.NewRouter()
+	handlers.Init(router, nil)
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/api/ping")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	var m map[string]bool
+	require.NoError(t, json.Unmarshal(body, &m))
+	assert.True(t, m["pong"])
+}
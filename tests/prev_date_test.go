@@ -0,0 +1,73 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/scheduler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrevDate(t *testing.T) {
+	// 26.01.2024 - пятница
+	now := time.Date(2024, time.January, 26, 12, 0, 0, 0, time.UTC)
+
+	tbl := []struct {
+		dstart string
+		repeat string
+		want   string
+	}{
+		// Ежедневно - сегодняшний день (не позже now включительно)
+		{"20240101", "d 1", "20240126"},
+		{"20240101", "d 3", "20240125"},
+		// Еженедельно
+		{"20240105", "d 7", "20240126"},
+		{"20240101", "w 1", "20240122"},
+		{"20240101", "w 1,3", "20240124"},
+		// Стартовая дата - единственное наступившее повторение
+		{"20240120", "d 10", "20240120"},
+		// Разовая задача
+		{"20240110", "", "20240110"},
+	}
+	for _, v := range tbl {
+		got, err := scheduler.PrevDate(now, v.dstart, v.repeat)
+		require.NoError(t, err, "%s %q", v.dstart, v.repeat)
+		assert.Equal(t, v.want, got, "%s %q", v.dstart, v.repeat)
+	}
+
+	// now раньше стартовой даты - предыдущего повторения нет
+	_, err := scheduler.PrevDate(now, "20240201", "d 1")
+	assert.ErrorIs(t, err, scheduler.ErrNoPrevDate)
+	_, err = scheduler.PrevDate(now, "20240127", "")
+	assert.ErrorIs(t, err, scheduler.ErrNoPrevDate)
+
+	_, err = scheduler.PrevDate(now, "20240101", "x 1")
+	assert.Error(t, err)
+}
+
+func TestTaskPrevDate(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	now := time.Now()
+	daily := insertTask(t, database, now.AddDate(0, 0, -5).Format(`20060102`), "Зарядка", "", "d 2")
+	upcoming := insertTask(t, database, now.AddDate(0, 0, 3).Format(`20060102`), "Будущая", "", "d 1")
+
+	prevDate := func(id string) *string {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/task/prev-date?id="+id, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var m map[string]*string
+		require.NoError(t, json.Unmarshal(body, &m))
+		return m["date"]
+	}
+
+	got := prevDate(daily)
+	require.NotNil(t, got)
+	assert.Equal(t, now.AddDate(0, 0, -1).Format(`20060102`), *got)
+
+	// Задача ещё не началась - предыдущего повторения нет
+	assert.Nil(t, prevDate(upcoming))
+}
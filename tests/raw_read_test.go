@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadsNeverMutateDates(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	// Просроченные задачи: повторяющаяся и разовая
+	recurring := insertTask(t, database, "20240101", "Еженедельная", "", "w 1")
+	once := insertTask(t, database, "20240105", "Разовая", "", "")
+	stored := map[string]string{recurring: "20240101", once: "20240105"}
+
+	listDates := func(path string) map[string]string {
+		code, body := doRequest(t, srv, http.MethodGet, path, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp struct {
+			Tasks []struct {
+				ID   string `json:"id"`
+				Date string `json:"date"`
+			} `json:"tasks"`
+		}
+		require.NoError(t, json.Unmarshal(body, &resp))
+		dates := map[string]string{}
+		for _, task := range resp.Tasks {
+			dates[task.ID] = task.Date
+		}
+		return dates
+	}
+
+	// Список (с raw=true и без него) возвращает хранимые даты без автоматического переноса
+	assert.Equal(t, stored, listDates("/api/tasks?raw=true"))
+	assert.Equal(t, stored, listDates("/api/tasks"))
+	code, _ := doRequest(t, srv, http.MethodGet, "/api/task?id="+recurring, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	// После чтений даты в БД не изменились
+	for id, date := range stored {
+		task, err := db.GetTask(database, id)
+		require.NoError(t, err)
+		assert.Equal(t, date, task.Date)
+	}
+
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/tasks?raw=true&date_format=iso", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/api/middleware"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyMode(t *testing.T) {
+	srv, database := newTestServer(t)
+	t.Cleanup(func() { middleware.SetReadOnly(false) })
+
+	id := insertTask(t, database, "20240126", "Задача", "", "")
+	task := map[string]any{"date": "today", "title": "Новая задача"}
+
+	// Включаем режим через административный эндпоинт
+	code, body := doRequest(t, srv, http.MethodPost, "/api/admin/read-only", map[string]any{"enabled": true})
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"read_only":true}`, string(body))
+
+	code, body = doRequest(t, srv, http.MethodPost, "/api/task", task)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Contains(t, string(body), "read-only")
+	code, _ = doRequest(t, srv, http.MethodPut, "/api/task", map[string]any{"id": id, "date": "today", "title": "Изменено"})
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task/done?id="+id, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	code, _ = doRequest(t, srv, http.MethodDelete, "/api/task?id="+id, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	// Чтение продолжает работать
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/tasks", nil)
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/task?id="+id, nil)
+	assert.Equal(t, http.StatusOK, code)
+
+	// После выключения режима запись снова разрешена
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/admin/read-only", map[string]any{"enabled": false})
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task", task)
+	assert.Equal(t, http.StatusCreated, code)
+	code, _ = doRequest(t, srv, http.MethodDelete, "/api/task?id="+id, nil)
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestReadOnlyMaintenanceWindow(t *testing.T) {
+	srv, _ := newTestServer(t)
+	t.Cleanup(func() { middleware.SetReadOnly(false) })
+
+	until := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second).Format(time.RFC3339)
+	code, body := doRequest(t, srv, http.MethodPost, "/api/admin/read-only", map[string]any{
+		"enabled":           true,
+		"maintenance_until": until,
+		"message":           "Переезд на новый сервер",
+	})
+	require.Equal(t, http.StatusOK, code, string(body))
+	assert.JSONEq(t, `{"read_only":true,"maintenance_until":"`+until+`","message":"Переезд на новый сервер"}`, string(body))
+
+	// Ответ 503 содержит окно обслуживания и сообщение
+	resp, body := doRequestWithHeaders(t, srv, http.MethodPost, "/api/task", map[string]any{"title": "Новая задача"}, nil)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	var m map[string]string
+	require.NoError(t, json.Unmarshal(body, &m))
+	assert.Equal(t, until, m["maintenance_until"])
+	assert.Equal(t, "Переезд на новый сервер", m["message"])
+	assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+
+	// Некорректное время окна отклоняется
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/admin/read-only", map[string]any{
+		"enabled":           true,
+		"maintenance_until": "завтра",
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// После выключения режима сведения об окне сбрасываются
+	code, body = doRequest(t, srv, http.MethodPost, "/api/admin/read-only", map[string]any{"enabled": false})
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"read_only":false}`, string(body))
+}
@@ -0,0 +1,51 @@
+package tests
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-task-manager-final_project/internal/server"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverer(t *testing.T) {
+	staticDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(staticDir, "index.html"), []byte("<html></html>"), 0o644))
+	t.Setenv("TODO_STATIC_DIR", staticDir)
+
+	router, err := server.NewRouter(nil)
+	require.NoError(t, err)
+	// Маршрут, добавленный к роутеру, проходит через те же middleware, что и остальные
+	router.Get("/panic", func(w http.ResponseWriter, r *http.Request) {
+		var tasks []string
+		_ = tasks[1]
+	})
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	get := func(path string) (int, string) {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	// Паника превращается в 500 с общим сообщением (без текста паники)
+	code, body := get("/panic")
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.JSONEq(t, `{"error":"internal server error"}`, body)
+
+	// Сервер продолжает обслуживать запросы
+	code, _ = get("/api/ping")
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = get("/panic")
+	assert.Equal(t, http.StatusInternalServerError, code)
+}
@@ -0,0 +1,72 @@
+package tests
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/config"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshToken(t *testing.T) {
+	prevPassword, prevSecret := config.Password, config.JWTSecret
+	t.Cleanup(func() { config.Password, config.JWTSecret = prevPassword, prevSecret })
+	config.Password, config.JWTSecret = "12345678", "test-secret"
+
+	srv, _ := newTestServer(t)
+
+	// Токен, подписанный так же, как при входе, но с заданным временем истечения
+	tokenExpiringAt := func(exp time.Time) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"authenticated": true,
+			"exp":           exp.Unix(),
+			"password_hash": fmt.Sprintf("%x", sha256.Sum256([]byte(config.Password))),
+		})
+		signed, err := token.SignedString([]byte(config.JWTSecret))
+		require.NoError(t, err)
+		return signed
+	}
+
+	// Действующий токен обновляется: новый токен истекает позже и устанавливается в cookie
+	oldExp := time.Now().Add(time.Hour)
+	resp, body := doRequestWithHeaders(t, srv, http.MethodPost, "/api/refresh", nil,
+		map[string]string{"Cookie": "token=" + tokenExpiringAt(oldExp)})
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	var refreshed map[string]string
+	require.NoError(t, json.Unmarshal(body, &refreshed))
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(refreshed["token"], claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(config.JWTSecret), nil
+	})
+	require.NoError(t, err)
+	exp, err := claims.GetExpirationTime()
+	require.NoError(t, err)
+	assert.True(t, exp.After(oldExp))
+
+	var cookie *http.Cookie
+	for _, c := range resp.Cookies() {
+		if c.Name == "token" {
+			cookie = c
+		}
+	}
+	require.NotNil(t, cookie)
+	assert.Equal(t, refreshed["token"], cookie.Value)
+
+	// Новый токен принимается защищёнными эндпоинтами
+	resp, _ = doRequestWithHeaders(t, srv, http.MethodGet, "/api/session", nil,
+		map[string]string{"Cookie": "token=" + refreshed["token"]})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Истёкший токен не обновляется
+	resp, _ = doRequestWithHeaders(t, srv, http.MethodPost, "/api/refresh", nil,
+		map[string]string{"Cookie": "token=" + tokenExpiringAt(time.Now().Add(-time.Minute))})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
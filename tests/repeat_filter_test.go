@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTasksRepeatFilter(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	insertTask(t, database, "20240126", "Полить цветы", "", "d 1")
+	insertTask(t, database, "20240127", "Выгулять собаку", "", "d 1")
+	insertTask(t, database, "20240128", "Уборка", "", "d 10")
+	insertTask(t, database, "20240129", "Разовая", "", "")
+
+	list := func(query string) []string {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/tasks?"+query, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp map[string][]map[string]any
+		require.NoError(t, json.Unmarshal(body, &resp))
+		titles := []string{}
+		for _, task := range resp["tasks"] {
+			titles = append(titles, task["title"].(string))
+		}
+		return titles
+	}
+
+	// "d 1" не совпадает с "d 10": сравнение точное
+	assert.ElementsMatch(t, []string{"Полить цветы", "Выгулять собаку"}, list("repeat=d+1"))
+	// Пробелы нормализуются
+	assert.ElementsMatch(t, []string{"Полить цветы", "Выгулять собаку"}, list("repeat=d++1+"))
+	assert.Equal(t, []string{"Уборка"}, list("repeat=d+10"))
+	// Пустое правило - задачи без повторения
+	assert.Equal(t, []string{"Разовая"}, list("repeat="))
+	assert.Empty(t, list("repeat=y"))
+
+	// Фильтр сочетается с поиском
+	assert.Equal(t, []string{"Выгулять собаку"}, list("repeat=d+1&search=собаку"))
+}
+
+func TestTasksRepeatFilterOrder(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	// Задачи добавлены не в порядке дат: страницы упорядочены по дате, а не по порядку вставки
+	insertTask(t, database, "20240128", "Третья", "", "d 1")
+	insertTask(t, database, "20240126", "Первая", "", "d 1")
+	insertTask(t, database, "20240127", "Вторая", "", "d 1")
+
+	titles := []string{}
+	for offset := range 3 {
+		code, body := doRequest(t, srv, http.MethodGet, fmt.Sprintf("/api/tasks?repeat=d+1&limit=1&offset=%d", offset), nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp map[string][]map[string]any
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.Len(t, resp["tasks"], 1)
+		titles = append(titles, resp["tasks"][0]["title"].(string))
+	}
+	assert.Equal(t, []string{"Первая", "Вторая", "Третья"}, titles)
+}
+
+func TestSearchRepeatFilterPagination(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	// Первые совпадения поиска - с другим правилом повторения, нужные задачи идут за ними
+	for range 5 {
+		insertTask(t, database, "20240101", "Отчёт", "", "d 1")
+	}
+	insertTask(t, database, "20240201", "Отчёт", "", "w 1")
+	insertTask(t, database, "20240202", "Отчёт", "", "w 1")
+	insertTask(t, database, "20240203", "Отчёт", "", "w 1")
+	insertTask(t, database, "20240201", "Другое", "", "w 1")
+
+	dates := func(query string) []string {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/tasks?"+query, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp map[string][]map[string]any
+		require.NoError(t, json.Unmarshal(body, &resp))
+		result := []string{}
+		for _, task := range resp["tasks"] {
+			result = append(result, task["date"].(string))
+		}
+		return result
+	}
+
+	// Отбор по правилу и страница вычисляются по всем совпадениям, а не по первой странице поиска
+	assert.Equal(t, []string{"20240201", "20240202"}, dates("search=Отчёт&repeat=w+1&limit=2"))
+	assert.Equal(t, []string{"20240203"}, dates("search=Отчёт&repeat=w+1&limit=2&offset=2"))
+	assert.Empty(t, dates("search=Отчёт&repeat=w+1&limit=2&offset=3"))
+
+	// То же для поиска по дате
+	assert.Equal(t, []string{"20240201", "20240201"}, dates("search=20240201&repeat=w+1"))
+	assert.Len(t, dates("search=20240201&repeat=w+1&limit=1&offset=1"), 1)
+	assert.Empty(t, dates("search=20240101&repeat=w+1"))
+}
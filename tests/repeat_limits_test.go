@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/config"
+	"go-task-manager-final_project/internal/scheduler"
+	"go-task-manager-final_project/internal/server"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepeatLimits(t *testing.T) {
+	prevDays, prevMonthDay := config.RepeatMaxDays, config.RepeatMaxMonthDay
+	t.Cleanup(func() {
+		config.RepeatMaxDays, config.RepeatMaxMonthDay = prevDays, prevMonthDay
+		require.NoError(t, scheduler.SetRepeatLimits(scheduler.DefaultRepeatLimits))
+	})
+
+	now := time.Date(2024, 1, 26, 0, 0, 0, 0, time.UTC)
+
+	// С границами по умолчанию правило допустимо
+	config.RepeatMaxDays, config.RepeatMaxMonthDay = "", ""
+	require.NoError(t, server.ConfigureRepeatLimits())
+	next, err := scheduler.NextDate(now, "20240126", "d 120")
+	require.NoError(t, err)
+	assert.Equal(t, "20240525", next)
+
+	// Пониженный предел интервала "d" отклоняет ранее допустимое правило
+	config.RepeatMaxDays = "90"
+	require.NoError(t, server.ConfigureRepeatLimits())
+	_, err = scheduler.NextDate(now, "20240126", "d 120")
+	assert.ErrorContains(t, err, "[1, 90]")
+	_, err = scheduler.DescribeRepeat("d 120", scheduler.LangEnglish)
+	assert.Error(t, err)
+	_, err = scheduler.NextDate(now, "20240126", "d 90")
+	assert.NoError(t, err)
+
+	// Граница применяется и к API
+	srv, _ := newTestServer(t)
+	code, _ := doRequest(t, srv, http.MethodGet, "/api/nextdate?now=20240126&date=20240126&repeat=d+120", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// Предел дня месяца в правиле "m"
+	config.RepeatMaxMonthDay = "28"
+	require.NoError(t, server.ConfigureRepeatLimits())
+	_, err = scheduler.NextDate(now, "20240126", "m 30")
+	assert.Error(t, err)
+	_, err = scheduler.NextDate(now, "20240126", "m 28,-1")
+	assert.NoError(t, err)
+
+	// Некорректные значения не принимаются при загрузке, действующие границы не меняются
+	for _, value := range []string{"abc", "0", "401"} {
+		config.RepeatMaxDays = value
+		assert.Error(t, server.ConfigureRepeatLimits(), value)
+	}
+	assert.Equal(t, 90, scheduler.CurrentRepeatLimits().MaxDays)
+	config.RepeatMaxDays, config.RepeatMaxMonthDay = "", "32"
+	assert.Error(t, server.ConfigureRepeatLimits())
+}
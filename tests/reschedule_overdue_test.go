@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRescheduleOverdue(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	now := time.Now()
+	today := now.Format(scheduler.DateFormat)
+	past := now.AddDate(0, 0, -10).Format(scheduler.DateFormat)
+	future := now.AddDate(0, 0, 10).Format(scheduler.DateFormat)
+
+	overdueOnce := insertTask(t, database, past, "Разовая просроченная", "", "")
+	overdueRepeat := insertTask(t, database, past, "Повторяющаяся просроченная", "", "d 3")
+	upcoming := insertTask(t, database, future, "Будущая", "", "")
+
+	dateOf := func(id string) string {
+		task, err := db.GetTask(database, id)
+		require.NoError(t, err)
+		return task.Date
+	}
+
+	// Сначала переносим только задачи без повторения
+	code, body := doRequest(t, srv, http.MethodPost, "/api/tasks/reschedule-overdue?once_only=true", nil)
+	require.Equal(t, http.StatusOK, code)
+	var resp map[string]int
+	require.NoError(t, json.Unmarshal(body, &resp))
+	assert.Equal(t, 1, resp["updated"])
+	assert.Equal(t, today, dateOf(overdueOnce))
+	assert.Equal(t, past, dateOf(overdueRepeat))
+
+	// Затем все оставшиеся просроченные
+	code, body = doRequest(t, srv, http.MethodPost, "/api/tasks/reschedule-overdue", nil)
+	require.Equal(t, http.StatusOK, code)
+	require.NoError(t, json.Unmarshal(body, &resp))
+	assert.Equal(t, 1, resp["updated"])
+	assert.Equal(t, today, dateOf(overdueRepeat))
+
+	// Будущие задачи не затрагиваются
+	assert.Equal(t, future, dateOf(upcoming))
+
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/tasks/reschedule-overdue?once_only=maybe", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
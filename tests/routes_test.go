@@ -0,0 +1,29 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"go-task-manager-final_project/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIPrefix(t *testing.T) {
+	prev := config.APIPrefix
+	config.APIPrefix = "/scheduler/"
+	t.Cleanup(func() { config.APIPrefix = prev })
+
+	srv, _ := newTestServer(t)
+
+	code, body := doRequest(t, srv, http.MethodGet, "/scheduler/nextdate?now=20240126&date=20240113&repeat=d+7", nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "20240127", string(body))
+
+	code, _ = doRequest(t, srv, http.MethodGet, "/scheduler/tasks", nil)
+	assert.Equal(t, http.StatusOK, code)
+
+	// Старый путь при заданном префиксе не обслуживается
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/tasks", nil)
+	assert.Equal(t, http.StatusNotFound, code)
+}
@@ -0,0 +1,54 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchComments(t *testing.T) {
+	prev := config.SearchComments
+	t.Cleanup(func() { config.SearchComments = prev })
+
+	srv, database := newTestServer(t)
+
+	today := time.Now().Format(`20060102`)
+	inComment := insertTask(t, database, today, "Визит к врачу", "взять медицинский полис", "")
+	inTitle := insertTask(t, database, today, "Продлить полис", "", "")
+
+	search := func(query string) []string {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/tasks?search="+url.QueryEscape(query), nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp struct {
+			Tasks []struct {
+				ID string `json:"id"`
+			} `json:"tasks"`
+		}
+		require.NoError(t, json.Unmarshal(body, &resp))
+		ids := make([]string, 0, len(resp.Tasks))
+		for _, task := range resp.Tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	// По умолчанию совпадение только в комментарии находится
+	config.SearchComments = ""
+	assert.ElementsMatch(t, []string{inComment, inTitle}, search("полис"))
+	assert.Equal(t, []string{inComment}, search("медицинский"))
+
+	config.SearchComments = "true"
+	assert.Equal(t, []string{inComment}, search("медицинский"))
+
+	// При отключении ищутся только заголовки
+	config.SearchComments = "false"
+	assert.Empty(t, search("медицинский"))
+	assert.Equal(t, []string{inTitle}, search("полис"))
+}
@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"go-task-manager-final_project/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchByISODate(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	insertTask(t, database, "20240115", "Встреча", "", "")
+	insertTask(t, database, "20240116", "Отчёт", "упомянуть 2024-01-15", "")
+
+	search := func(query string) []string {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/tasks?search="+query, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp map[string][]map[string]any
+		require.NoError(t, json.Unmarshal(body, &resp))
+		titles := []string{}
+		for _, task := range resp["tasks"] {
+			titles = append(titles, task["title"].(string))
+		}
+		return titles
+	}
+
+	// ISO-дата - фильтр по дате, а не поиск подстроки
+	assert.Equal(t, []string{"Встреча"}, search("2024-01-15"))
+	assert.Equal(t, []string{"Встреча"}, search("15.01.2024"))
+
+	// Набор форматов настраивается: без ISO запрос ищется как текст
+	config.SearchDateFormats = "20060102"
+	t.Cleanup(func() { config.SearchDateFormats = "" })
+	assert.Equal(t, []string{"Отчёт"}, search("2024-01-15"))
+	assert.Equal(t, []string{"Встреча"}, search("20240115"))
+}
+
+func TestSearchScansWholeTable(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	// Искомые задачи идут после первых 50 строк таблицы
+	for i := 0; i < 60; i++ {
+		insertTask(t, database, "20240101", fmt.Sprintf("Задача %d", i), "", "")
+	}
+	insertTask(t, database, "20240320", "Старый отчёт", "", "")
+
+	for _, query := range []string{"20.03.2024", "20240320", "Старый"} {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/tasks?search="+url.QueryEscape(query), nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp map[string][]map[string]any
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.Len(t, resp["tasks"], 1, query)
+		assert.Equal(t, "Старый отчёт", resp["tasks"][0]["title"])
+		assert.Equal(t, "20240320", resp["tasks"][0]["date"])
+	}
+}
@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchOperators(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	insertTask(t, database, "20240126", "Купить продукты", "groceries, urgent", "")
+	insertTask(t, database, "20240127", "Купить продукты на неделю", "groceries", "")
+	insertTask(t, database, "20240128", "Убраться", "home", "")
+	insertTask(t, database, "20240129", "Отчёт", "work", "")
+	insertTask(t, database, "20240130", "Позвонить", "100% срочно", "")
+
+	search := func(query string) []string {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/tasks?search="+url.QueryEscape(query), nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp map[string][]map[string]any
+		require.NoError(t, json.Unmarshal(body, &resp))
+		titles := []string{}
+		for _, task := range resp["tasks"] {
+			titles = append(titles, task["title"].(string))
+		}
+		return titles
+	}
+
+	t.Run("AND", func(t *testing.T) {
+		assert.Equal(t, []string{"Купить продукты"}, search("groceries AND urgent"))
+	})
+	t.Run("OR", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"Убраться", "Отчёт"}, search("home OR work"))
+	})
+	t.Run("quoted phrase", func(t *testing.T) {
+		assert.Equal(t, []string{"Купить продукты на неделю"}, search(`"продукты на" OR nothing`))
+		assert.Equal(t, []string{"Купить продукты на неделю"}, search(`"продукты на"`))
+	})
+	t.Run("plain substring", func(t *testing.T) {
+		// Без операторов запрос ищется целиком, без учёта регистра (в том числе для кириллицы)
+		assert.Len(t, search("КУПИТЬ ПРОДУКТЫ"), 2)
+		assert.Empty(t, search("продукты неделю"))
+		// Спецсимволы LIKE ищутся буквально
+		assert.Equal(t, []string{"Позвонить"}, search("100%"))
+		assert.Empty(t, search("1_0"))
+	})
+
+	code, _ := doRequest(t, srv, http.MethodGet, "/api/tasks?search="+url.QueryEscape("home OR"), nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-task-manager-final_project/config"
+	"go-task-manager-final_project/internal/server"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecurityHeaders(t *testing.T) {
+	staticDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(staticDir, "index.html"), []byte("<html></html>"), 0o644))
+	t.Setenv("TODO_STATIC_DIR", staticDir)
+
+	newServer := func() *httptest.Server {
+		router, err := server.NewRouter(nil)
+		require.NoError(t, err)
+		srv := httptest.NewServer(router)
+		t.Cleanup(srv.Close)
+		return srv
+	}
+
+	get := func(srv *httptest.Server, path string) http.Header {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		return resp.Header
+	}
+
+	srv := newServer()
+	for _, path := range []string{"/api/ping", "/index.html"} {
+		header := get(srv, path)
+		assert.Equal(t, "nosniff", header.Get("X-Content-Type-Options"), path)
+		assert.Equal(t, "DENY", header.Get("X-Frame-Options"), path)
+		assert.Contains(t, header.Get("Content-Security-Policy"), "default-src 'self'", path)
+	}
+
+	// Политика настраивается через TODO_CSP, значение "off" отключает заголовок
+	prev := config.ContentSecurityPolicy
+	t.Cleanup(func() { config.ContentSecurityPolicy = prev })
+
+	config.ContentSecurityPolicy = "default-src *"
+	assert.Equal(t, "default-src *", get(newServer(), "/index.html").Get("Content-Security-Policy"))
+
+	config.ContentSecurityPolicy = "off"
+	header := get(newServer(), "/api/ping")
+	assert.Empty(t, header.Get("Content-Security-Policy"))
+	assert.Equal(t, "nosniff", header.Get("X-Content-Type-Options"))
+}
@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfTest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.db")
+	database, err := db.Init(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	// Рабочая БД проходит самопроверку, пробная задача не остаётся в таблице
+	require.NoError(t, db.SelfTest(database))
+	var total int
+	require.NoError(t, database.QueryRow(`SELECT COUNT(*) FROM scheduler`).Scan(&total))
+	assert.Zero(t, total)
+
+	// БД, открытая только для чтения, не проходит самопроверку
+	readOnly, err := sql.Open("sqlite", "file:"+path+"?mode=ro")
+	require.NoError(t, err)
+	t.Cleanup(func() { readOnly.Close() })
+	err = db.SelfTest(readOnly)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "insert probe task")
+
+	// Повреждённая схема (нет таблицы задач) тоже обнаруживается
+	_, err = database.Exec(`DROP TABLE scheduler`)
+	require.NoError(t, err)
+	assert.Error(t, db.SelfTest(database))
+}
@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession(t *testing.T) {
+	prevPassword, prevSecret := config.Password, config.JWTSecret
+	t.Cleanup(func() { config.Password, config.JWTSecret = prevPassword, prevSecret })
+	config.Password, config.JWTSecret = "12345678", "test-secret"
+
+	srv, _ := newTestServer(t)
+
+	code, body := doRequest(t, srv, http.MethodPost, "/api/signin", map[string]any{"password": "12345678"})
+	require.Equal(t, http.StatusOK, code, string(body))
+	var signin map[string]string
+	require.NoError(t, json.Unmarshal(body, &signin))
+
+	// Действительный токен - сессия с временем истечения из токена
+	resp, body := doRequestWithHeaders(t, srv, http.MethodGet, "/api/session", nil,
+		map[string]string{"Cookie": "token=" + signin["token"]})
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	var session struct {
+		Authenticated bool  `json:"authenticated"`
+		ExpiresAt     int64 `json:"expires_at"`
+	}
+	require.NoError(t, json.Unmarshal(body, &session))
+	assert.True(t, session.Authenticated)
+	assert.InDelta(t, time.Now().Add(8*time.Hour).Unix(), session.ExpiresAt, 60)
+
+	// Без токена и с недействительным токеном - 401
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/session", nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	resp, _ = doRequestWithHeaders(t, srv, http.MethodGet, "/api/session", nil,
+		map[string]string{"Cookie": "token=not-a-jwt"})
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
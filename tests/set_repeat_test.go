@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetRepeat(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	now := time.Now()
+	past := now.AddDate(0, 0, -10).Format(`20060102`)
+	future := now.AddDate(0, 0, 3).Format(`20060102`)
+
+	overdue := insertTask(t, database, past, "Вынести мусор", "", "")
+	upcoming := insertTask(t, database, future, "Полить цветы", "", "")
+	untouched := insertTask(t, database, past, "Разовая", "", "")
+
+	ids := func(values ...string) []int64 {
+		result := make([]int64, 0, len(values))
+		for _, value := range values {
+			id, err := strconv.ParseInt(value, 10, 64)
+			require.NoError(t, err)
+			result = append(result, id)
+		}
+		return result
+	}
+
+	code, body := doRequest(t, srv, http.MethodPost, "/api/tasks/set-repeat", map[string]any{
+		"ids":    ids(overdue, upcoming),
+		"repeat": "w 1",
+	})
+	require.Equal(t, http.StatusOK, code, string(body))
+	var resp map[string]int64
+	require.NoError(t, json.Unmarshal(body, &resp))
+	assert.Equal(t, int64(2), resp["updated"])
+
+	// Дата в прошлом пересчитана по новому правилу, дата в будущем сохранена
+	expected, err := scheduler.NextDate(now, past, "w 1")
+	require.NoError(t, err)
+	task, err := db.GetTask(database, overdue)
+	require.NoError(t, err)
+	assert.Equal(t, "w 1", task.Repeat)
+	assert.Equal(t, expected, task.Date)
+
+	task, err = db.GetTask(database, upcoming)
+	require.NoError(t, err)
+	assert.Equal(t, "w 1", task.Repeat)
+	assert.Equal(t, future, task.Date)
+
+	// Некорректное правило отклоняется до изменения задач
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/tasks/set-repeat", map[string]any{
+		"ids":    ids(untouched),
+		"repeat": "w 8",
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+
+	// Несуществующая задача отменяет изменение всех задач из запроса
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/tasks/set-repeat", map[string]any{
+		"ids":    []int64{ids(untouched)[0], 999999},
+		"repeat": "d 3",
+	})
+	assert.Equal(t, http.StatusNotFound, code)
+
+	task, err = db.GetTask(database, untouched)
+	require.NoError(t, err)
+	assert.Empty(t, task.Repeat)
+	assert.Equal(t, past, task.Date)
+}
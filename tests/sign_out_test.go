@@ -0,0 +1,34 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignOut(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	resp, body := doRequestWithHeaders(t, srv, http.MethodPost, "/api/signout", nil,
+		map[string]string{"Cookie": "token=some-token"})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{}`, string(body))
+
+	// Set-Cookie сбрасывает токен: пустое значение и Max-Age=0 (MaxAge=-1 в net/http)
+	setCookie := resp.Header.Get("Set-Cookie")
+	assert.Contains(t, setCookie, "token=;")
+	assert.Contains(t, setCookie, "Max-Age=0")
+
+	var token *http.Cookie
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == "token" {
+			token = cookie
+		}
+	}
+	require.NotNil(t, token)
+	assert.Empty(t, token.Value)
+	assert.Equal(t, -1, token.MaxAge)
+	assert.Equal(t, "/", token.Path)
+}
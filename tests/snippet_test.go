@@ -0,0 +1,46 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go-task-manager-final_project/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchSnippet(t *testing.T) {
+	prev := config.SnippetLength
+	config.SnippetLength = "20"
+	t.Cleanup(func() { config.SnippetLength = prev })
+
+	srv, database := newTestServer(t)
+
+	insertTask(t, database, "20240126", "Длинная", strings.Repeat("а", 100)+"Target"+strings.Repeat("б", 100), "")
+	insertTask(t, database, "20240127", "Короткая", "в начале target", "")
+
+	comments := func(query string) map[string]string {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/tasks?search="+query, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp map[string][]map[string]any
+		require.NoError(t, json.Unmarshal(body, &resp))
+		res := map[string]string{}
+		for _, task := range resp["tasks"] {
+			res[task["title"].(string)], _ = task["comment"].(string)
+		}
+		return res
+	}
+
+	got := comments("target&snippet=true")
+	// Фрагмент из 20 символов, совпадение по центру и выделено маркерами
+	assert.Equal(t, "…"+strings.Repeat("а", 7)+"[[Target]]"+strings.Repeat("б", 7)+"…", got["Длинная"])
+	// Короткий текст не обрезается, окно прижимается к границам
+	assert.Equal(t, "в начале [[target]]", got["Короткая"])
+
+	// Без snippet возвращается полный комментарий
+	got = comments("target")
+	assert.Len(t, []rune(got["Длинная"]), 206)
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+	"go-task-manager-final_project/internal/scheduler"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextWeekday(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	// Следующий понедельник от разных дней недели (22.01.2024 - понедельник)
+	for _, tc := range []struct {
+		now  time.Time
+		want string
+	}{
+		{time.Date(2024, 1, 22, 10, 0, 0, 0, time.UTC), "20240129"},  // понедельник - через неделю
+		{time.Date(2024, 1, 23, 10, 0, 0, 0, time.UTC), "20240129"},  // вторник
+		{time.Date(2024, 1, 27, 10, 0, 0, 0, time.UTC), "20240129"},  // суббота
+		{time.Date(2024, 1, 28, 23, 0, 0, 0, time.UTC), "20240129"},  // воскресенье, поздний вечер
+		{time.Date(2024, 1, 29, 1, 0, 0, 0, moscow), "20240205"},     // понедельник по местному времени (в UTC ещё воскресенье)
+		{time.Date(2024, 12, 31, 10, 0, 0, 0, time.UTC), "20250106"}, // переход через год
+	} {
+		got, err := scheduler.NextWeekday(tc.now, 1)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got, tc.now.String())
+	}
+
+	got, err := scheduler.NextWeekday(time.Date(2024, 1, 22, 10, 0, 0, 0, time.UTC), 7)
+	require.NoError(t, err)
+	assert.Equal(t, "20240128", got)
+
+	for _, weekday := range []int{0, 8} {
+		_, err = scheduler.NextWeekday(time.Now(), weekday)
+		assert.Error(t, err, weekday)
+	}
+}
+
+func TestSnoozeWeekday(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	id := insertTask(t, database, "20240126", "Позвонить", "", "w 3")
+	want, err := scheduler.NextWeekday(time.Now(), 1)
+	require.NoError(t, err)
+
+	code, body := doRequest(t, srv, http.MethodPost, "/api/task/snooze-weekday?id="+id+"&weekday=1", nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+	assert.JSONEq(t, `{"date":"`+want+`"}`, string(body))
+
+	// Дата изменилась, правило повторения - нет
+	task, err := db.GetTask(database, id)
+	require.NoError(t, err)
+	assert.Equal(t, want, task.Date)
+	assert.Equal(t, "w 3", task.Repeat)
+
+	for _, query := range []string{"id=" + id + "&weekday=0", "id=" + id + "&weekday=8", "id=" + id, "weekday=1"} {
+		code, _ = doRequest(t, srv, http.MethodPost, "/api/task/snooze-weekday?"+query, nil)
+		assert.Equal(t, http.StatusBadRequest, code, query)
+	}
+}
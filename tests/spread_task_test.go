@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpreadTask(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	dates := []string{"20990201", "20990215", "20990301"}
+	code, body := doRequest(t, srv, http.MethodPost, "/api/task/spread", map[string]any{
+		"title": "Поменять фильтр",
+		"dates": dates,
+	})
+	require.Equal(t, http.StatusCreated, code, string(body))
+
+	var resp map[string][]int64
+	require.NoError(t, json.Unmarshal(body, &resp))
+	require.Len(t, resp["ids"], 3)
+
+	for i, id := range resp["ids"] {
+		task, err := db.GetTask(database, strconv.FormatInt(id, 10))
+		require.NoError(t, err)
+		assert.Equal(t, "Поменять фильтр", task.Title)
+		assert.Equal(t, dates[i], task.Date)
+	}
+
+	// Некорректная дата отклоняет весь запрос: ни одна задача не создаётся
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task/spread", map[string]any{
+		"title": "Ещё одна",
+		"dates": []string{"20990401", "2099-04-15"},
+	})
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+
+	var count int
+	require.NoError(t, database.QueryRow(`SELECT COUNT(*) FROM scheduler`).Scan(&count))
+	assert.Equal(t, 3, count)
+
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task/spread", map[string]any{"title": "Без дат"})
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+}
+
+func TestSpreadTaskBestEffort(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	code, body := doRequest(t, srv, http.MethodPost, "/api/task/spread?mode=besteffort", map[string]any{
+		"title": "Полить цветы",
+		"dates": []string{"20990501", "2099-05-08", "20990515"},
+	})
+	require.Equal(t, http.StatusOK, code, string(body))
+
+	var resp struct {
+		Results []struct {
+			Index int    `json:"index"`
+			Date  string `json:"date"`
+			ID    int64  `json:"id"`
+			Error string `json:"error"`
+		} `json:"results"`
+		Succeeded int `json:"succeeded"`
+		Failed    int `json:"failed"`
+	}
+	require.NoError(t, json.Unmarshal(body, &resp))
+	assert.Equal(t, 2, resp.Succeeded)
+	assert.Equal(t, 1, resp.Failed)
+	require.Len(t, resp.Results, 3)
+
+	// Ошибка по одной дате не мешает создать задачи на остальные
+	assert.NotZero(t, resp.Results[0].ID)
+	assert.Empty(t, resp.Results[0].Error)
+	assert.Zero(t, resp.Results[1].ID)
+	assert.Contains(t, resp.Results[1].Error, "2099-05-08")
+	assert.NotZero(t, resp.Results[2].ID)
+
+	task, err := db.GetTask(database, strconv.FormatInt(resp.Results[2].ID, 10))
+	require.NoError(t, err)
+	assert.Equal(t, "20990515", task.Date)
+
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task/spread?mode=partial", map[string]any{
+		"title": "Полить цветы",
+		"dates": []string{"20990501"},
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go-task-manager-final_project/internal/server"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionedStatic(t *testing.T) {
+	staticDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(staticDir, "index.html"), []byte("<html></html>"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(staticDir, "css"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(staticDir, "css", "style.css"), []byte("body{}"), 0o644))
+	t.Setenv("TODO_STATIC_DIR", staticDir)
+
+	prev := server.Version
+	t.Cleanup(func() { server.Version = prev })
+	server.Version = "1.2.3"
+
+	router, err := server.NewRouter(nil)
+	require.NoError(t, err)
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	get := func(path string) (*http.Response, string) {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(body)
+	}
+
+	// Файл под версионным префиксом берётся из той же директории и кэшируется надолго
+	resp, body := get("/static/1.2.3/css/style.css")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "body{}", body)
+	assert.Equal(t, "public, max-age=31536000, immutable", resp.Header.Get("Cache-Control"))
+
+	// index.html не кэшируется ни по версионному, ни по обычному пути
+	for _, path := range []string{"/", "/static/1.2.3/"} {
+		resp, body = get(path)
+		require.Equal(t, http.StatusOK, resp.StatusCode, path)
+		assert.Equal(t, "<html></html>", body, path)
+		assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"), path)
+	}
+
+	// Обычный путь к файлу работает как раньше, без долгого кэширования
+	resp, _ = get("/css/style.css")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Cache-Control"))
+
+	// Другая версия в пути не обслуживается префиксом
+	resp, _ = get("/static/0.0.1/css/style.css")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// insertCompletion добавляет отметку о выполнении задачи, запланированной и выполненной в указанные дни.
+func insertCompletion(t *testing.T, database *sql.DB, id string, scheduled, completed time.Time) {
+	t.Helper()
+
+	_, err := database.Exec(`INSERT INTO completions (task_id, scheduled_date, completed_at) VALUES (?, ?, ?)`,
+		id, scheduled.Format(`20060102`), completed.Unix())
+	require.NoError(t, err)
+}
+
+func getStreak(t *testing.T, get func(string) (int, []byte), id string) map[string]int {
+	t.Helper()
+
+	code, body := get("/api/task/streak?id=" + id)
+	require.Equal(t, http.StatusOK, code, string(body))
+	var m map[string]int
+	require.NoError(t, json.Unmarshal(body, &m))
+	return m
+}
+
+func TestStreak(t *testing.T) {
+	srv, database := newTestServer(t)
+	get := func(path string) (int, []byte) { return doRequest(t, srv, http.MethodGet, path, nil) }
+
+	now := time.Now()
+
+	// Три выполнения подряд в срок
+	id := insertTask(t, database, now.Format(`20060102`), "Зарядка", "", "d 1")
+	for i := 3; i >= 1; i-- {
+		day := now.AddDate(0, 0, -i)
+		insertCompletion(t, database, id, day, day)
+	}
+	streak := getStreak(t, get, id)
+	assert.Equal(t, 3, streak["current"])
+	assert.Equal(t, 3, streak["longest"])
+
+	// Пропущенное повторение прерывает серию
+	id = insertTask(t, database, now.Format(`20060102`), "Чтение", "", "d 1")
+	for _, i := range []int{5, 4, 2, 1} {
+		day := now.AddDate(0, 0, -i)
+		insertCompletion(t, database, id, day, day)
+	}
+	streak = getStreak(t, get, id)
+	assert.Equal(t, 2, streak["current"])
+	assert.Equal(t, 2, streak["longest"])
+
+	// Выполнение через API сохраняет отметку
+	code, _ := doRequest(t, srv, http.MethodPost, "/api/task/done?id="+id, nil)
+	require.Equal(t, http.StatusOK, code)
+	streak = getStreak(t, get, id)
+	assert.Equal(t, 3, streak["current"])
+}
@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTasksSummaryView(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	insertTask(t, database, "20240126", "Прочитать книгу", "Очень длинный комментарий", "d 5")
+	insertTask(t, database, "20240127", "Сходить в магазин", "Молоко, хлеб", "")
+
+	list := func(path string) []map[string]any {
+		code, body := doRequest(t, srv, http.MethodGet, path, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp map[string][]map[string]any
+		require.NoError(t, json.Unmarshal(body, &resp))
+		return resp["tasks"]
+	}
+
+	full := list("/api/tasks")
+	require.Len(t, full, 2)
+	for _, task := range full {
+		assert.Contains(t, task, "comment")
+	}
+
+	summary := list("/api/tasks?view=summary")
+	require.Len(t, summary, 2)
+	for _, task := range summary {
+		assert.NotContains(t, task, "comment")
+		assert.Contains(t, task, "title")
+		assert.Contains(t, task, "date")
+	}
+	assert.Equal(t, "d 5", summary[0]["repeat"])
+
+	// Постраничный вывод
+	page := list("/api/tasks?view=summary&offset=1")
+	require.Len(t, page, 1)
+	assert.Equal(t, "Сходить в магазин", page[0]["title"])
+
+	// С поиском облегчённое представление тоже без комментария
+	found := list("/api/tasks?view=summary&search=книгу")
+	require.Len(t, found, 1)
+	assert.NotContains(t, found[0], "comment")
+
+	code, _ := doRequest(t, srv, http.MethodGet, "/api/tasks?view=compact", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/tasks?view=summary&offset=-1", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
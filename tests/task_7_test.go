@@ -32,7 +32,13 @@ func TestDone(t *testing.T) {
 	ret, err := postJSON("api/task/done?id="+id, nil, http.MethodPost)
 	assert.NoError(t, err)
 	assert.Empty(t, ret)
-	notFoundTask(t, id)
+
+	// Разовая задача не удаляется, а получает состояние done
+	body, err := requestJSON("api/task?id="+id, nil, http.MethodGet)
+	assert.NoError(t, err)
+	var done map[string]string
+	assert.NoError(t, json.Unmarshal(body, &done))
+	assert.Equal(t, "done", done["status"])
 
 	id = addTask(t, task{
 		title:  "Проверить работу /api/task/done",
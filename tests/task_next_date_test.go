@@ -0,0 +1,46 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskNextDate(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	now := time.Now()
+	past := now.AddDate(0, 0, -10)
+	future := now.AddDate(0, 0, 3).Format(`20060102`)
+
+	nextDate := func(id string) string {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/task/next-date?id="+id, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var m map[string]string
+		require.NoError(t, json.Unmarshal(body, &m))
+		return m["date"]
+	}
+
+	// Просроченная повторяющаяся задача - первая дата по правилу строго после сегодняшней (дата задачи + 15 дней)
+	recurring := insertTask(t, database, past.Format(`20060102`), "Каждые 5 дней", "", "d 5")
+	assert.Equal(t, past.AddDate(0, 0, 15).Format(`20060102`), nextDate(recurring))
+
+	// Разовая задача - её собственная дата
+	once := insertTask(t, database, past.Format(`20060102`), "Разовая", "", "")
+	assert.Equal(t, past.Format(`20060102`), nextDate(once))
+
+	// Повторяющаяся задача в будущем - её собственная дата
+	upcoming := insertTask(t, database, future, "Будущая", "", "d 5")
+	assert.Equal(t, future, nextDate(upcoming))
+
+	// Задача не изменяется
+	task, err := db.GetTask(database, recurring)
+	require.NoError(t, err)
+	assert.Equal(t, past.Format(`20060102`), task.Date)
+}
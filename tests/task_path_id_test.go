@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskPathID(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	id := insertTask(t, database, "20300101", "Задача", "Комментарий", "")
+
+	// Получение задачи по id в пути
+	code, body := doRequest(t, srv, http.MethodGet, "/api/task/"+id, nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+	var task map[string]any
+	require.NoError(t, json.Unmarshal(body, &task))
+	assert.Equal(t, id, task["id"])
+	assert.Equal(t, "Задача", task["title"])
+
+	// Обновление: id берётся из пути, в теле его можно не передавать
+	code, body = doRequest(t, srv, http.MethodPut, "/api/task/"+id, map[string]any{
+		"date":  "20300102",
+		"title": "Изменённая задача",
+	})
+	require.Equal(t, http.StatusOK, code, string(body))
+	stored, err := db.GetTask(database, id)
+	require.NoError(t, err)
+	assert.Equal(t, "20300102", stored.Date)
+	assert.Equal(t, "Изменённая задача", stored.Title)
+
+	// id в теле, не совпадающий с id в пути, отклоняется
+	code, _ = doRequest(t, srv, http.MethodPut, "/api/task/"+id, map[string]any{
+		"id":    id + "0",
+		"date":  "20300102",
+		"title": "Другая задача",
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// Некорректный и несуществующий id
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/task/abc", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/task/999999", nil)
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = doRequest(t, srv, http.MethodPut, "/api/task/999999", map[string]any{"date": "20300102", "title": "Задача"})
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// Удаление по id в пути
+	code, _ = doRequest(t, srv, http.MethodDelete, "/api/task/"+id, nil)
+	require.Equal(t, http.StatusOK, code)
+	code, _ = doRequest(t, srv, http.MethodDelete, "/api/task/"+id, nil)
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// Формы со строкой запроса продолжают работать, а вложенные пути не перехватываются параметром
+	id = insertTask(t, database, "20300101", "Задача", "", "")
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/task?id="+id, nil)
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = doRequest(t, srv, http.MethodGet, "/api/task/next-date?id="+id, nil)
+	assert.Equal(t, http.StatusOK, code)
+}
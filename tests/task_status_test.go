@@ -0,0 +1,83 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoneSetsStatus(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	once := insertTask(t, database, "20300101", "Разовая задача", "", "")
+	repeating := insertTask(t, database, "20300101", "Периодическая задача", "", "d 2")
+
+	// Разовая задача остаётся в БД с состоянием done
+	code, body := doRequest(t, srv, http.MethodPost, "/api/task/done?id="+once, nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+	task, err := db.GetTask(database, once)
+	require.NoError(t, err)
+	assert.Equal(t, db.StatusDone, task.Status)
+	assert.Equal(t, "20300101", task.Date)
+
+	// Повторная отметка ничего не меняет
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task/done?id="+once, nil)
+	assert.Equal(t, http.StatusOK, code)
+
+	// Периодическая задача переносится на следующую дату и остаётся активной
+	code, body = doRequest(t, srv, http.MethodPost, "/api/task/done?id="+repeating, nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+	task, err = db.GetTask(database, repeating)
+	require.NoError(t, err)
+	assert.Equal(t, db.StatusPending, task.Status)
+	assert.Equal(t, "20300103", task.Date)
+
+	// Отсутствующая задача - ошибка ErrTaskNotFound
+	assert.ErrorIs(t, db.UpdateStatus(database, "999999", db.StatusDone), db.ErrTaskNotFound)
+	assert.Error(t, db.UpdateStatus(database, once, "archived"))
+}
+
+func TestTasksStatusFilter(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	pending := insertTask(t, database, "20300101", "Активная задача", "", "")
+	done := insertTask(t, database, "20300102", "Выполненная задача", "", "")
+	require.NoError(t, db.UpdateStatus(database, done, db.StatusDone))
+
+	ids := func(path string) []string {
+		code, body := doRequest(t, srv, http.MethodGet, path, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp struct {
+			Tasks []struct {
+				ID     string `json:"id"`
+				Status string `json:"status"`
+			} `json:"tasks"`
+		}
+		require.NoError(t, json.Unmarshal(body, &resp))
+		result := []string{}
+		for _, task := range resp.Tasks {
+			result = append(result, task.ID+":"+task.Status)
+		}
+		return result
+	}
+
+	// По умолчанию выводятся только активные задачи
+	assert.Equal(t, []string{pending + ":pending"}, ids("/api/tasks"))
+	assert.Equal(t, []string{pending + ":pending"}, ids("/api/tasks?status=pending"))
+	assert.Equal(t, []string{done + ":done"}, ids("/api/tasks?status=done"))
+	assert.Equal(t, []string{pending + ":pending", done + ":done"}, ids("/api/tasks?status=all"))
+
+	// Фильтр сочетается с поиском и отбором по правилу повторения
+	assert.Equal(t, []string{done + ":done"}, ids("/api/tasks?status=done&search=задача"))
+	assert.Equal(t, []string{done + ":done"}, ids("/api/tasks?status=done&search=20300102"))
+	assert.Empty(t, ids("/api/tasks?search=20300102"))
+	assert.Equal(t, []string{done + ":done"}, ids("/api/tasks?status=done&repeat="))
+
+	code, _ := doRequest(t, srv, http.MethodGet, "/api/tasks?status=archived", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
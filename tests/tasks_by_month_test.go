@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTasksByMonth(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	insertTask(t, database, "20240105", "Январь 1", "", "")
+	insertTask(t, database, "20240131", "Январь 2", "", "")
+	insertTask(t, database, "20240301", "Март", "", "")
+	insertTask(t, database, "20231231", "Прошлый год", "", "")
+	insertTask(t, database, "20250101", "Следующий год", "", "")
+
+	byMonth := func(query string) map[string][]map[string]any {
+		code, body := doRequest(t, srv, http.MethodGet, "/api/tasks/by-month?"+query, nil)
+		require.Equal(t, http.StatusOK, code, string(body))
+		var resp map[string][]map[string]any
+		require.NoError(t, json.Unmarshal(body, &resp))
+		return resp
+	}
+
+	months := byMonth("year=2024")
+	assert.Len(t, months, 2)
+	require.Len(t, months["2024-01"], 2)
+	assert.Equal(t, "Январь 1", months["2024-01"][0]["title"])
+	assert.Equal(t, "Январь 2", months["2024-01"][1]["title"])
+	require.Len(t, months["2024-03"], 1)
+	assert.Equal(t, "Март", months["2024-03"][0]["title"])
+
+	months = byMonth("year=2024&include_empty=true")
+	assert.Len(t, months, 12)
+	assert.Empty(t, months["2024-02"])
+	assert.Len(t, months["2024-01"], 2)
+
+	code, _ := doRequest(t, srv, http.MethodGet, "/api/tasks/by-month?year=abc", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
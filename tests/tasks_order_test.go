@@ -0,0 +1,35 @@
+package tests
+
+import (
+	"testing"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTasksOrderedByDate(t *testing.T) {
+	_, database := newTestServer(t)
+
+	// Задачи добавляются не по порядку дат
+	late := insertTask(t, database, "20240310", "Поздняя", "", "")
+	early := insertTask(t, database, "20240105", "Ранняя", "", "")
+	middle1 := insertTask(t, database, "20240201", "Средняя 1", "", "")
+	middle2 := insertTask(t, database, "20240201", "Средняя 2", "", "")
+
+	tasks, err := db.GetTasks(database, 10, 0)
+	require.NoError(t, err)
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	// По возрастанию даты, при равных датах - по ID
+	assert.Equal(t, []string{early, middle1, middle2, late}, ids)
+
+	// Ограничение по количеству сохраняется
+	tasks, err = db.GetTasks(database, 2, 0)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, early, tasks[0].ID)
+}
@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"database/sql"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskTimestamps(t *testing.T) {
+	srv, database := newTestServer(t)
+
+	const old = "2020-01-01T00:00:00Z"
+	task := func(id string) *db.Task {
+		stored, err := db.GetTask(database, id)
+		require.NoError(t, err)
+		return stored
+	}
+	// resetUpdated сдвигает время изменения в прошлое, чтобы заметить его обновление
+	resetUpdated := func(id string) {
+		_, err := database.Exec(`UPDATE scheduler SET updated_at = ? WHERE id = ?`, old, id)
+		require.NoError(t, err)
+	}
+
+	// При создании оба времени заполняются и совпадают
+	id, err := db.AddTask(database, &db.Task{Date: "20300101", Title: "Задача", Repeat: "d 1"})
+	require.NoError(t, err)
+	created := task(strconv.FormatInt(id, 10))
+	_, err = time.Parse(time.RFC3339, created.CreatedAt)
+	require.NoError(t, err)
+	assert.Equal(t, created.CreatedAt, created.UpdatedAt)
+
+	// Обновление задачи через API меняет только время изменения
+	resetUpdated(created.ID)
+	code, body := doRequest(t, srv, http.MethodPut, "/api/task", map[string]any{
+		"id":         created.ID,
+		"date":       "20300102",
+		"title":      "Изменённая задача",
+		"repeat":     "d 1",
+		"created_at": old,
+	})
+	require.Equal(t, http.StatusOK, code, string(body))
+	updated := task(created.ID)
+	assert.Equal(t, created.CreatedAt, updated.CreatedAt)
+	assert.NotEqual(t, old, updated.UpdatedAt)
+
+	// Перенос даты при выполнении периодической задачи тоже обновляет время изменения
+	resetUpdated(created.ID)
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task/done?id="+created.ID, nil)
+	require.Equal(t, http.StatusOK, code)
+	assert.NotEqual(t, old, task(created.ID).UpdatedAt)
+
+	resetUpdated(created.ID)
+	require.NoError(t, db.UpdateDate(database, "20300110", created.ID))
+	assert.NotEqual(t, old, task(created.ID).UpdatedAt)
+}
+
+func TestTimestampColumnsAddedToExistingDB(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "old.db")
+
+	// БД без колонок created_at и updated_at
+	old, err := sql.Open("sqlite", dbFile)
+	require.NoError(t, err)
+	_, err = old.Exec(`CREATE TABLE scheduler (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		date CHAR(8) NOT NULL DEFAULT '',
+		title VARCHAR(255) NOT NULL,
+		comment TEXT,
+		repeat VARCHAR(128)
+	)`)
+	require.NoError(t, err)
+	_, err = old.Exec(`INSERT INTO scheduler (date, title, comment, repeat) VALUES ('20240126', 'Старая задача', '', '')`)
+	require.NoError(t, err)
+	require.NoError(t, old.Close())
+
+	database, err := db.Init(dbFile)
+	require.NoError(t, err)
+	defer database.Close()
+
+	// У старой задачи время неизвестно, новые задачи получают его как обычно
+	tasks, err := db.GetTasks(database, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Empty(t, tasks[0].CreatedAt)
+	assert.Empty(t, tasks[0].UpdatedAt)
+
+	id, err := db.AddTask(database, &db.Task{Date: "20240127", Title: "Новая задача"})
+	require.NoError(t, err)
+	created, err := db.GetTask(database, strconv.FormatInt(id, 10))
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.CreatedAt)
+}
@@ -0,0 +1,31 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrailingSlash(t *testing.T) {
+	srv, database := newTestServer(t)
+	id := insertTask(t, database, "20240126", "Задача", "", "")
+
+	for _, path := range []string{
+		"/api/tasks",
+		"/api/tasks/",
+		"/api/task?id=" + id,
+		"/api/task/?id=" + id,
+		"/api/nextdate?now=20240126&date=20240126&repeat=d+1",
+		"/api/nextdate/?now=20240126&date=20240126&repeat=d+1",
+	} {
+		code, body := doRequest(t, srv, http.MethodGet, path, nil)
+		assert.Equal(t, http.StatusOK, code, "%s: %s", path, body)
+	}
+
+	// Изменяющие запросы тоже обслуживаются без перенаправления
+	code, _ := doRequest(t, srv, http.MethodPost, "/api/task/", map[string]any{"title": "Со слэшем"})
+	assert.Equal(t, http.StatusCreated, code)
+	code, _ = doRequest(t, srv, http.MethodDelete, "/api/task/?id="+id, nil)
+	assert.Equal(t, http.StatusOK, code)
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"errors"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// countBusyErrors выполняет параллельные транзакции (чтение, затем запись) и возвращает число ошибок SQLITE_BUSY.
+func countBusyErrors(t *testing.T, immediate bool) int64 {
+	t.Helper()
+
+	db.SetTxImmediate(immediate)
+	database, err := db.Init(filepath.Join(t.TempDir(), "scheduler.db"))
+	db.SetTxImmediate(false)
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	// Ожидание занятой БД задаётся в обоих режимах
+	var timeout int
+	require.NoError(t, database.QueryRow(`PRAGMA busy_timeout`).Scan(&timeout))
+	assert.Equal(t, 5000, timeout)
+
+	const workers, rounds = 8, 10
+	ids := make([]int64, workers)
+	for i := range ids {
+		id, err := strconv.ParseInt(insertTask(t, database, "20240126", "Задача", "", ""), 10, 64)
+		require.NoError(t, err)
+		ids[i] = id
+	}
+
+	var busy, other atomic.Int64
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Add(1)
+		go func(id int64) {
+			defer wg.Done()
+			for range rounds {
+				// SetRepeat в одной транзакции читает дату задачи и затем обновляет её
+				_, err := db.SetRepeat(database, []int64{id}, "d 1", func(date string) (string, error) {
+					return date, nil
+				})
+				var sqliteErr *sqlite.Error
+				switch {
+				case err == nil:
+				case errors.As(err, &sqliteErr) && sqliteErr.Code()&0xff == sqlite3.SQLITE_BUSY:
+					busy.Add(1)
+				default:
+					other.Add(1)
+				}
+			}
+		}(ids[i])
+	}
+	wg.Wait()
+
+	// Кроме SQLITE_BUSY ошибок быть не должно: в частности, подключение не возвращается в пул
+	// с незавершённой транзакцией ("cannot start a transaction within a transaction")
+	assert.Zero(t, other.Load())
+	return busy.Load()
+}
+
+func TestTxImmediate(t *testing.T) {
+	t.Cleanup(func() { db.SetTxImmediate(false) })
+
+	deferred := countBusyErrors(t, false)
+	immediate := countBusyErrors(t, true)
+	t.Logf("SQLITE_BUSY errors: deferred=%d, immediate=%d", deferred, immediate)
+
+	// С BEGIN IMMEDIATE транзакции ждут блокировку записи, а не падают посреди работы
+	// (в отложенном режиме число ошибок зависит от планирования горутин и не проверяется)
+	assert.Zero(t, immediate)
+}
@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"net/http"
+	"testing"
+
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniqueTitlePerDate(t *testing.T) {
+	srv, database := newTestServer(t)
+	require.NoError(t, db.SetUniqueTitlePerDate(database, true))
+
+	task := map[string]any{"date": "today", "title": "Купить хлеб"}
+
+	code, _ := doRequest(t, srv, http.MethodPost, "/api/task", task)
+	require.Equal(t, http.StatusCreated, code)
+
+	// Тот же заголовок на ту же дату - конфликт
+	code, body := doRequest(t, srv, http.MethodPost, "/api/task", task)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Contains(t, string(body), "conflicts")
+
+	// Тот же заголовок на другую дату допустим
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task", map[string]any{"date": "20990101", "title": "Купить хлеб"})
+	assert.Equal(t, http.StatusCreated, code)
+}
+
+func TestUniqueTitlePerDateExistingDuplicates(t *testing.T) {
+	_, database := newTestServer(t)
+
+	insertTask(t, database, "20240126", "Дубликат", "", "")
+	insertTask(t, database, "20240126", "Дубликат", "", "")
+
+	err := db.SetUniqueTitlePerDate(database, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Дубликат")
+
+	// Отключение флага не требует уникальности
+	assert.NoError(t, db.SetUniqueTitlePerDate(database, false))
+}
@@ -0,0 +1,38 @@
+package tests
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskTextUTF8(t *testing.T) {
+	srv, database := newTestServer(t)
+	id := insertTask(t, database, "20240126", "Задача", "", "")
+
+	// rawRequest отправляет тело как есть: json.Marshal заменил бы некорректные байты на U+FFFD
+	rawRequest := func(method string, body []byte) int {
+		req, err := http.NewRequest(method, srv.URL+"/api/task", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	invalidTitle := append([]byte(`{"date":"today","title":"bad `), 0xff, 0xfe)
+	invalidTitle = append(invalidTitle, []byte(`"}`)...)
+	assert.Equal(t, http.StatusBadRequest, rawRequest(http.MethodPost, invalidTitle))
+
+	invalidComment := append([]byte(`{"id":"`+id+`","date":"today","title":"ok","comment":"`), 0xc3, 0x28)
+	invalidComment = append(invalidComment, []byte(`"}`)...)
+	assert.Equal(t, http.StatusBadRequest, rawRequest(http.MethodPut, invalidComment))
+
+	// Корректный многобайтовый текст принимается
+	assert.Equal(t, http.StatusCreated, rawRequest(http.MethodPost, []byte(`{"date":"today","title":"Купить 🍎 и 茶"}`)))
+	assert.Equal(t, http.StatusOK, rawRequest(http.MethodPut, []byte(`{"id":"`+id+`","date":"today","title":"Ünïcödé","comment":"日本語"}`)))
+}
@@ -0,0 +1,75 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"go-task-manager-final_project/config"
+	"go-task-manager-final_project/internal/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVacuum(t *testing.T) {
+	prevAdmin := config.AdminEndpoints
+	t.Cleanup(func() { config.AdminEndpoints = prevAdmin })
+	config.AdminEndpoints = ""
+
+	srv, database := newTestServer(t)
+
+	// Заполняем БД и удаляем большую часть задач, чтобы в файле осталось свободное место
+	comment := strings.Repeat("x", 2000)
+	var ids []string
+	for i := 0; i < 200; i++ {
+		ids = append(ids, insertTask(t, database, "20240126", fmt.Sprintf("Задача %d", i), comment, ""))
+	}
+	for _, id := range ids[10:] {
+		require.NoError(t, db.DeleteTask(database, id))
+	}
+
+	// Без флага административные эндпоинты закрыты (в том числе без пароля, когда аутентификация отключена)
+	code, _ := doRequest(t, srv, http.MethodGet, "/api/admin/db-size", nil)
+	assert.Equal(t, http.StatusForbidden, code)
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/admin/vacuum", nil)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	// Размер БД и количество задач
+	config.AdminEndpoints = "true"
+	code, body := doRequest(t, srv, http.MethodGet, "/api/admin/db-size", nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+	var size struct {
+		SizeBytes int64 `json:"size_bytes"`
+		Tasks     int64 `json:"tasks"`
+	}
+	require.NoError(t, json.Unmarshal(body, &size))
+	assert.Equal(t, int64(10), size.Tasks)
+	assert.Greater(t, size.SizeBytes, int64(200*1000))
+
+	// С флагом VACUUM выполняется и уменьшает размер БД
+	code, body = doRequest(t, srv, http.MethodPost, "/api/admin/vacuum", nil)
+	require.Equal(t, http.StatusOK, code, string(body))
+	var vacuum struct {
+		SizeBefore int64 `json:"size_before"`
+		SizeAfter  int64 `json:"size_after"`
+	}
+	require.NoError(t, json.Unmarshal(body, &vacuum))
+	assert.Equal(t, size.SizeBytes, vacuum.SizeBefore)
+	assert.Less(t, vacuum.SizeAfter, vacuum.SizeBefore)
+
+	// Размер совпадает с размером файла БД
+	var file string
+	require.NoError(t, database.QueryRow(`SELECT file FROM pragma_database_list WHERE name = 'main'`).Scan(&file))
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	assert.Equal(t, info.Size(), vacuum.SizeAfter)
+
+	// Задачи после VACUUM на месте
+	count, err := db.CountTasks(database)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), count)
+}
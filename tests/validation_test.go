@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationStatusCodes(t *testing.T) {
+	srv, database := newTestServer(t)
+	id := insertTask(t, database, "20240126", "Задача", "", "")
+
+	// Некорректный JSON - 400
+	for _, method := range []string{http.MethodPost, http.MethodPut} {
+		req, err := http.NewRequest(method, srv.URL+"/api/task", bytes.NewBufferString(`{"title":`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := srv.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, method)
+	}
+
+	// Корректный JSON с пустым заголовком или неверной датой - 422
+	code, _ := doRequest(t, srv, http.MethodPost, "/api/task", map[string]any{"date": "today", "title": ""})
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	code, _ = doRequest(t, srv, http.MethodPost, "/api/task", map[string]any{"date": "20240192", "title": "Задача"})
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	code, _ = doRequest(t, srv, http.MethodPut, "/api/task", map[string]any{"id": id, "date": "today", "title": " "})
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+	code, _ = doRequest(t, srv, http.MethodPut, "/api/task", map[string]any{"id": id, "date": "20240112", "title": "Задача", "repeat": "ooops"})
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+}
@@ -0,0 +1,63 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"go-task-manager-final_project/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoWeekendTasks(t *testing.T) {
+	prev := config.NoWeekendTasks
+	t.Cleanup(func() { config.NoWeekendTasks = prev })
+
+	srv, _ := newTestServer(t)
+
+	// Ближайшая суббота в будущем и следующий за ней понедельник
+	saturday := time.Now().AddDate(0, 0, 1)
+	for saturday.Weekday() != time.Saturday {
+		saturday = saturday.AddDate(0, 0, 1)
+	}
+	monday := saturday.AddDate(0, 0, 2)
+	task := map[string]any{"date": saturday.Format(`20060102`), "title": "Субботник"}
+
+	create := func() (int, string) {
+		code, body := doRequest(t, srv, http.MethodPost, "/api/task", task)
+		var resp struct {
+			Task struct {
+				Date string `json:"date"`
+			} `json:"task"`
+		}
+		require.NoError(t, json.Unmarshal(body, &resp))
+		return code, resp.Task.Date
+	}
+
+	// По умолчанию задачи на выходные разрешены
+	config.NoWeekendTasks = ""
+	code, date := create()
+	require.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, saturday.Format(`20060102`), date)
+
+	// Запрет - 422
+	config.NoWeekendTasks = "reject"
+	code, _ = create()
+	assert.Equal(t, http.StatusUnprocessableEntity, code)
+
+	// Перенос - дата сдвигается на понедельник
+	config.NoWeekendTasks = "roll_forward"
+	code, date = create()
+	require.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, monday.Format(`20060102`), date)
+
+	// Будний день не затрагивается
+	task["date"] = monday.Format(`20060102`)
+	config.NoWeekendTasks = "reject"
+	code, date = create()
+	require.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, monday.Format(`20060102`), date)
+}